@@ -0,0 +1,236 @@
+package tscache
+
+import (
+	"container/list"
+)
+
+// Lists of the ARC policy
+const (
+	arcT1 = iota // Resident items seen once recently
+	arcT2        // Resident items seen at least twice
+	arcB1        // Ghost entries of items evicted from T1
+	arcB2        // Ghost entries of items evicted from T2
+)
+
+// ARCNode represents a resident item or a ghost entry tracked by the ARC policy.
+type ARCNode struct {
+	Key    string     // Cache key for this node
+	Item   *CacheItem // Reference to the actual cache item (nil for ghost entries)
+	Weight int        // Memory weight of the item (at least 1)
+	List   int        // List currently holding the node
+}
+
+// ARCList implements the Adaptive Replacement Cache eviction policy. Resident
+// items are split between T1, holding items seen once recently, and T2, holding
+// items seen at least twice. Evicted keys are remembered in the ghost lists B1
+// and B2. A miss on a B1 ghost means T1 was too small and grows the T1 target;
+// a miss on a B2 ghost shrinks it, so the policy continuously shifts between
+// recency and frequency to match the workload.
+//
+// All sizes are measured in bytes of item weight rather than item counts, with
+// the shard's memory limit as the capacity: the target for T1 lies between 0 and
+// the capacity, T1 plus B1 is kept within the capacity and all four lists together
+// within twice the capacity.
+//
+// Time Complexity:
+//   - Add: O(1) amortized
+//   - Remove: O(1) with hash map lookup
+//   - Update: O(1)
+//   - RemoveLeast: O(1) amortized
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type ARCList struct {
+	capacity int                      // Capacity in bytes (the shard memory limit)
+	target   int                      // Adaptive target size of T1 in bytes
+	lists    [4]*list.List            // T1, T2, B1 and B2 LRU lists (front = most recent)
+	weights  [4]int                   // Total weight held by each list
+	nodeMap  map[string]*list.Element // Hash map for O(1) key-to-node lookup, resident and ghost
+}
+
+// NewARCList creates a new ARC eviction list.
+//
+// Parameters:
+//   - capacity: Capacity in bytes, normally the shard memory limit
+//
+// Returns:
+//   - *ARCList: A new ARC list ready for use
+func NewARCList(capacity int) *ARCList {
+	arc := &ARCList{capacity: max(capacity, 0)}
+	arc.Clear()
+	return arc
+}
+
+// SetCapacity changes the capacity of the ARC list.
+//
+// Parameters:
+//   - capacity: Capacity in bytes, normally the shard memory limit
+//
+// The T1 target is clamped to the new capacity and ghost entries beyond it are dropped.
+func (arc *ARCList) SetCapacity(capacity int) {
+	arc.capacity = max(capacity, 0)
+	arc.target = min(arc.target, arc.capacity)
+	arc.trimGhosts()
+}
+
+// Add inserts a new resident item.
+//
+// Parameters:
+//   - key: Cache key identifier
+//   - item: Cache item to add or update
+//
+// A key found in a ghost list adapts the T1 target and enters T2. Otherwise the
+// item enters T1, unless it carries accesses over from an item it replaces, in
+// which case it keeps its place among the frequently used items in T2. Adding a
+// resident key counts as an access.
+func (arc *ARCList) Add(key string, item *CacheItem) {
+	weight := max(item.Size, 1)
+
+	if element, exists := arc.nodeMap[key]; exists {
+		node := element.Value.(*ARCNode)
+		switch node.List {
+		case arcT1, arcT2:
+			arc.weights[node.List] += weight - node.Weight
+			node.Weight = weight
+			arc.Update(key, item)
+			return
+		case arcB1:
+			// T1 was too small to keep this item: favor recency
+			delta := max(arc.weights[arcB2]/max(arc.weights[arcB1], 1), 1) * weight
+			arc.target = min(arc.target+delta, arc.capacity)
+		case arcB2:
+			// T2 was too small to keep this item: favor frequency
+			delta := max(arc.weights[arcB1]/max(arc.weights[arcB2], 1), 1) * weight
+			arc.target = max(arc.target-delta, 0)
+		}
+
+		arc.unlink(element)
+		arc.push(&ARCNode{Key: key, Item: item, Weight: weight}, arcT2)
+		arc.trimGhosts()
+		return
+	}
+
+	dest := arcT1
+	if item.AccessCount > 0 {
+		dest = arcT2
+	}
+	arc.push(&ARCNode{Key: key, Item: item, Weight: weight}, dest)
+	arc.trimGhosts()
+}
+
+// Remove deletes a resident item from the ARC list.
+//
+// Parameters:
+//   - key: Cache key to remove
+//
+// Explicitly removed items leave no ghost entry, since their removal says
+// nothing about the size of T1 or T2. Ghost entries are left untouched.
+func (arc *ARCList) Remove(key string) {
+	if element, exists := arc.nodeMap[key]; exists {
+		if node := element.Value.(*ARCNode); node.List == arcT1 || node.List == arcT2 {
+			arc.unlink(element)
+		}
+	}
+}
+
+// Update records an access to a resident item.
+//
+// Parameters:
+//   - key: Cache key to update
+//   - item: Updated cache item
+//
+// Items in T1 are promoted to T2; items in T2 move to its front.
+func (arc *ARCList) Update(key string, item *CacheItem) {
+	element, exists := arc.nodeMap[key]
+	if !exists {
+		return
+	}
+
+	node := element.Value.(*ARCNode)
+	switch node.List {
+	case arcT1:
+		node.Item = item
+		arc.unlink(element)
+		arc.push(node, arcT2)
+	case arcT2:
+		node.Item = item
+		arc.lists[arcT2].MoveToFront(element)
+	}
+}
+
+// RemoveLeast evicts the least recently used item of T1 or T2.
+//
+// Returns:
+//   - string: Key of the evicted item, empty string if list is empty
+//
+// T1 is chosen when it exceeds its target size or T2 is empty. The evicted key
+// is remembered in the matching ghost list.
+func (arc *ARCList) RemoveLeast() string {
+	from, ghost := arcT2, arcB2
+	if arc.lists[arcT1].Len() > 0 && (arc.weights[arcT1] > arc.target || arc.lists[arcT2].Len() == 0) {
+		from, ghost = arcT1, arcB1
+	}
+
+	element := arc.lists[from].Back()
+	if element == nil {
+		return ""
+	}
+
+	node := element.Value.(*ARCNode)
+	arc.unlink(element)
+	node.Item = nil
+	arc.push(node, ghost)
+	arc.trimGhosts()
+
+	return node.Key
+}
+
+// Clear removes all items and ghost entries from the ARC list and resets its target.
+func (arc *ARCList) Clear() {
+	for i := range arc.lists {
+		arc.lists[i] = list.New()
+		arc.weights[i] = 0
+	}
+	arc.nodeMap = make(map[string]*list.Element)
+	arc.target = 0
+}
+
+// push inserts a node at the front of a list.
+//
+// Parameters:
+//   - node: Node to insert
+//   - dest: Destination list
+func (arc *ARCList) push(node *ARCNode, dest int) {
+	node.List = dest
+	arc.nodeMap[node.Key] = arc.lists[dest].PushFront(node)
+	arc.weights[dest] += node.Weight
+}
+
+// unlink removes a node from its list and from the key mapping.
+//
+// Parameters:
+//   - element: List element of the node
+func (arc *ARCList) unlink(element *list.Element) {
+	node := element.Value.(*ARCNode)
+	arc.lists[node.List].Remove(element)
+	arc.weights[node.List] -= node.Weight
+	delete(arc.nodeMap, node.Key)
+}
+
+// trimGhosts drops the oldest ghost entries until T1 plus B1 fits in the
+// capacity and all lists together fit in twice the capacity.
+func (arc *ARCList) trimGhosts() {
+	for arc.lists[arcB1].Len() > 0 && arc.weights[arcT1]+arc.weights[arcB1] > arc.capacity {
+		arc.unlink(arc.lists[arcB1].Back())
+	}
+	for arc.lists[arcB2].Len() > 0 && arc.totalWeight() > 2*arc.capacity {
+		arc.unlink(arc.lists[arcB2].Back())
+	}
+	for arc.lists[arcB1].Len() > 0 && arc.totalWeight() > 2*arc.capacity {
+		arc.unlink(arc.lists[arcB1].Back())
+	}
+}
+
+// totalWeight returns the weight held by all four lists.
+func (arc *ARCList) totalWeight() int {
+	return arc.weights[arcT1] + arc.weights[arcT2] + arc.weights[arcB1] + arc.weights[arcB2]
+}
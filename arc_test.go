@@ -0,0 +1,142 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestARCList(t *testing.T) {
+	arc := NewARCList(100)
+
+	for i := 0; i < 4; i++ {
+		key := fmt.Sprintf("key%d", i)
+		arc.Add(key, &CacheItem{Key: key, Size: 10})
+	}
+
+	// 被访问过的数据进入T2，优先淘汰T1
+	arc.Update("key0", &CacheItem{Key: "key0", Size: 10})
+	arc.Remove("key3")
+	arc.Remove("nonexistent") // 不应该出错
+
+	for _, want := range []string{"key1", "key2", "key0", ""} {
+		if got := arc.RemoveLeast(); got != want {
+			t.Errorf("RemoveLeast() = %q, want %q", got, want)
+		}
+	}
+
+	// 被淘汰的key保留为ghost，不再是驻留数据
+	if node := arc.nodeMap["key1"].Value.(*ARCNode); node.List != arcB1 || node.Item != nil {
+		t.Errorf("key1 should be a ghost in B1, got list %d", node.List)
+	}
+	if node := arc.nodeMap["key0"].Value.(*ARCNode); node.List != arcB2 {
+		t.Errorf("key0 should be a ghost in B2, got list %d", node.List)
+	}
+	if _, exists := arc.nodeMap["key3"]; exists {
+		t.Error("explicitly removed keys should not leave a ghost")
+	}
+}
+
+func TestARCAdaptation(t *testing.T) {
+	arc := NewARCList(100)
+
+	add := func(key string) {
+		arc.Add(key, &CacheItem{Key: key, Size: 10})
+	}
+
+	add("recent")
+	add("frequent")
+	arc.Update("frequent", &CacheItem{Key: "frequent", Size: 10})
+	arc.RemoveLeast() // recent -> B1
+	arc.RemoveLeast() // frequent -> B2
+
+	// B1命中说明T1过小，目标值增大
+	add("recent")
+	if arc.target != 10 {
+		t.Errorf("target after B1 hit = %d, want 10", arc.target)
+	}
+	if node := arc.nodeMap["recent"].Value.(*ARCNode); node.List != arcT2 {
+		t.Errorf("ghost hit should enter T2, got list %d", node.List)
+	}
+
+	// B2命中说明T2过小，目标值减小
+	add("frequent")
+	if arc.target != 0 {
+		t.Errorf("target after B2 hit = %d, want 0", arc.target)
+	}
+}
+
+func TestARCByteCapacity(t *testing.T) {
+	const capacity = 1000
+	arc := NewARCList(capacity)
+	sizes := make(map[string]int)
+	resident := 0
+
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%d", i)
+		sizes[key] = 10 + i%90
+		arc.Add(key, &CacheItem{Key: key, Size: sizes[key]})
+		resident += sizes[key]
+		if i%3 == 0 {
+			arc.Update(key, &CacheItem{Key: key, Size: sizes[key]})
+		}
+
+		// 按字节数淘汰，直到驻留数据不超过容量
+		for resident > capacity {
+			resident -= sizes[arc.RemoveLeast()]
+		}
+
+		// ghost列表受容量约束
+		if arc.weights[arcT1]+arc.weights[arcB1] > capacity && arc.lists[arcB1].Len() > 0 {
+			t.Fatalf("T1+B1 = %d exceeds capacity", arc.weights[arcT1]+arc.weights[arcB1])
+		}
+		if total := arc.totalWeight(); total > 2*capacity {
+			t.Fatalf("total weight %d exceeds twice the capacity", total)
+		}
+		if arc.target < 0 || arc.target > capacity {
+			t.Fatalf("target %d out of range", arc.target)
+		}
+	}
+}
+
+func TestARCScanResistance(t *testing.T) {
+	keys := scanWorkload(50, 10000)
+
+	countHot := func(resident map[string]bool) int {
+		survivors := 0
+		for i := 0; i < 50; i++ {
+			if resident[fmt.Sprintf("hot%d", i)] {
+				survivors++
+			}
+		}
+		return survivors
+	}
+
+	arc := countHot(simulateEviction(NewARCList(100), 100, keys).resident)
+	lru := countHot(simulateEviction(NewLRUList(), 100, keys).resident)
+
+	if arc <= lru {
+		t.Errorf("ARC kept %d hot keys, LRU kept %d; ARC should keep more", arc, lru)
+	}
+}
+
+func TestARCCacheIntegration(t *testing.T) {
+	cache := NewCache(WithMaxSize(64*1024), WithEvictionPolicy(EvictionARC))
+	if policy := cache.Stats().EvictionPolicy; policy != EvictionARC {
+		t.Fatalf("EvictionPolicy = %s, want %s", policy, EvictionARC)
+	}
+
+	value := make([]byte, 100)
+	for _, key := range scanWorkload(100, 20000) {
+		if _, err := cache.Get(key); err != nil {
+			cache.Set(key, value, 0)
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.CurrentSize > stats.MaxSize {
+		t.Errorf("CurrentSize %d exceeds MaxSize %d", stats.CurrentSize, stats.MaxSize)
+	}
+	if stats.Hits == 0 || stats.Evictions == 0 {
+		t.Errorf("Hits = %d, Evictions = %d; want both non-zero", stats.Hits, stats.Evictions)
+	}
+}
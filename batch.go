@@ -0,0 +1,170 @@
+package tscache
+
+import (
+	"time"
+)
+
+// GetMany retrieves several values at once.
+//
+// Parameters:
+//   - keys: The cache keys to lookup
+//
+// Returns:
+//   - map[string][]byte: The values found, keyed by cache key
+//
+// Keys are grouped by shard and each shard lock is taken once for the whole group.
+// Missing, expired and undecodable keys are absent from the result; hits and misses
+// are counted exactly as with Get. Stale items and items in the refresh-ahead part of
+// their lifetime start a background refresh through the loader registered with
+// WithLoader, as with Get.
+func (c *Cache) GetMany(keys []string) map[string][]byte {
+	result := make(map[string][]byte, len(keys))
+	for shard, shardKeys := range c.groupByShard(keys) {
+		shard.getMany(shardKeys, result)
+	}
+	return result
+}
+
+// SetMany stores several values with the same TTL.
+//
+// Parameters:
+//   - items: The values to store, keyed by cache key
+//   - ttl: Time to live duration (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success, error if operation fails
+//
+// Keys are grouped by shard and each shard lock is taken once for the whole group.
+// Every value is stored exactly as with Set, including compression and eviction.
+func (c *Cache) SetMany(items map[string][]byte, ttl time.Duration) error {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+
+	for shard, shardKeys := range c.groupByShard(keys) {
+		shard.setMany(shardKeys, items, ttl)
+	}
+	return nil
+}
+
+// DeleteMany removes several keys at once.
+//
+// Parameters:
+//   - keys: The cache keys to remove
+//
+// Returns:
+//   - int: Number of items actually removed
+//
+// Keys are grouped by shard and each shard lock is taken once for the whole group.
+// Cached "not found" results under the given keys are dropped without being counted.
+func (c *Cache) DeleteMany(keys []string) int {
+	removed := 0
+	for shard, shardKeys := range c.groupByShard(keys) {
+		removed += shard.deleteMany(shardKeys)
+	}
+	return removed
+}
+
+// groupByShard partitions keys by the shard responsible for them.
+//
+// Parameters:
+//   - keys: Cache keys to partition
+//
+// Returns:
+//   - map[*CacheShard][]string: Keys for each shard that owns at least one of them
+func (c *Cache) groupByShard(keys []string) map[*CacheShard][]string {
+	groups := make(map[*CacheShard][]string)
+	for _, key := range keys {
+		shard := c.getShard(key)
+		groups[shard] = append(groups[shard], key)
+	}
+	return groups
+}
+
+// getMany looks up several keys under a single lock acquisition.
+//
+// Parameters:
+//   - keys: Cache keys owned by this shard
+//   - result: Map receiving the values that were found
+//
+// Values are decompressed and refreshes started after the lock has been released. Keys
+// holding a cached "not found" result are left out of result and counted as negative
+// hits, as in Get.
+func (s *CacheShard) getMany(keys []string, result map[string][]byte) {
+	now := time.Now()
+	found := make([]*CacheItem, 0, len(keys))
+	var (
+		reads     readStats
+		refreshes []*CacheItem
+	)
+
+	s.mu.Lock()
+	for _, key := range keys {
+		item, stale, refresh := s.accessLocked(key, now, false, &reads)
+		if refresh {
+			refreshes = append(refreshes, item)
+		}
+		if item == nil || stale || item.negative {
+			continue
+		}
+		found = append(found, item)
+	}
+	s.unlock()
+
+	s.recordReads(reads)
+	for _, item := range refreshes {
+		s.refreshWithLoader(item)
+	}
+
+	for _, item := range found {
+		if value, err := s.decode(item); err == nil {
+			result[item.Key] = value
+		}
+	}
+}
+
+// setMany stores several values under a single lock acquisition.
+//
+// Parameters:
+//   - keys: Cache keys owned by this shard
+//   - values: Values to store, keyed by cache key
+//   - ttl: Time to live (0 for no expiration)
+//
+// Values are compressed before the lock is taken.
+func (s *CacheShard) setMany(keys []string, values map[string][]byte, ttl time.Duration) {
+	now := time.Now()
+	items := make([]*CacheItem, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, s.newItem(key, values[key], ttl, now))
+	}
+
+	s.mu.Lock()
+	for _, item := range items {
+		s.storeLocked(item)
+	}
+	s.unlock()
+}
+
+// deleteMany removes several keys under a single lock acquisition.
+//
+// Parameters:
+//   - keys: Cache keys owned by this shard
+//
+// Returns:
+//   - int: Number of items removed, not counting negative items
+func (s *CacheShard) deleteMany(keys []string) int {
+	s.mu.Lock()
+	defer s.unlock()
+
+	removed := 0
+	for _, key := range keys {
+		if item, exists := s.data[key]; exists {
+			s.deleteLocked(key, item, Deleted)
+			if !item.negative {
+				removed++
+			}
+		}
+	}
+	return removed
+}
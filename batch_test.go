@@ -0,0 +1,99 @@
+package tscache
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCacheSetManyGetMany(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	items := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		items[fmt.Sprintf("key%d", i)] = toBytes(fmt.Sprintf("value%d", i))
+	}
+
+	if err := cache.SetMany(items, 0); err != nil {
+		t.Fatalf("SetMany failed: %v", err)
+	}
+
+	keys := []string{"key1", "key50", "key99", "missing"}
+	result := cache.GetMany(keys)
+	if len(result) != 3 {
+		t.Errorf("len(result) = %d, want 3", len(result))
+	}
+	for _, key := range keys[:3] {
+		if string(result[key]) != string(items[key]) {
+			t.Errorf("result[%s] = %s, want %s", key, string(result[key]), string(items[key]))
+		}
+	}
+
+	// 命中与未命中按key统计
+	stats := cache.Stats()
+	if stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("Hits = %d, Misses = %d, want 3 and 1", stats.Hits, stats.Misses)
+	}
+}
+
+func TestCacheSetManyMatchesSet(t *testing.T) {
+	shardMaxSize := 256
+	huge := toBytes(strings.Repeat("x", shardMaxSize+1))
+
+	// 超过分片上限的值与Set的处理方式相同：写入成功后被淘汰
+	single := NewCache(WithMaxSize(shardMaxSize * getOptimalShardCount()))
+	if err := single.Set("huge", huge, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	batch := NewCache(WithMaxSize(shardMaxSize * getOptimalShardCount()))
+	small := "small"
+	for i := 0; batch.getShard(small) == batch.getShard("huge"); i++ {
+		small = fmt.Sprintf("small%d", i)
+	}
+	if err := batch.SetMany(map[string][]byte{small: toBytes("ok"), "huge": huge}, 0); err != nil {
+		t.Fatalf("SetMany failed: %v", err)
+	}
+
+	setStats, batchStats := single.Stats(), batch.Stats()
+	if setStats.Evictions != 1 || batchStats.Evictions != setStats.Evictions {
+		t.Errorf("Evictions: Set %d, SetMany %d; want 1 for both", setStats.Evictions, batchStats.Evictions)
+	}
+
+	// 其余key正常写入
+	if _, err := batch.Get(small); err != nil {
+		t.Errorf("%s should be stored: %v", small, err)
+	}
+}
+
+func TestCacheDeleteMany(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	for i := 0; i < 10; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), toBytes("value"), 0)
+	}
+
+	removed := cache.DeleteMany([]string{"key0", "key1", "key2", "missing"})
+	if removed != 3 {
+		t.Errorf("DeleteMany removed %d, want 3", removed)
+	}
+
+	stats := cache.Stats()
+	if stats.CurrentCount != 7 || stats.CurrentSize != 7*len("value") {
+		t.Errorf("CurrentCount = %d, CurrentSize = %d", stats.CurrentCount, stats.CurrentSize)
+	}
+}
+
+func BenchmarkCacheGetMany(b *testing.B) {
+	cache := NewCache(WithMaxSize(1024 * 1024 * 100))
+
+	keys := make([]string, 200)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("fragment_%d", i)
+		cache.Set(keys[i], toBytes("rendered fragment"), 0)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.GetMany(keys)
+	}
+}
@@ -1,7 +1,7 @@
 // Package tscache provides a high-performance, thread-safe, in-memory cache library for Go.
 //
 // TSCache is designed for production use with features including:
-// - Multiple eviction policies (LRU, LFU, FIFO)
+// - Multiple eviction policies (LRU, LFU, FIFO, W-TinyLFU, ARC, SIEVE, S3-FIFO, GDSF, sampled allkeys-lru, allkeys-lfu, volatile-lru, volatile-ttl)
 // - Memory-based size limits with automatic eviction
 // - TTL (Time To Live) support for cache entries
 // - Data compression for large values
@@ -19,6 +19,7 @@
 package tscache
 
 import (
+	"sync"
 	"time"
 )
 
@@ -30,6 +31,24 @@ const (
 	EvictionLFU = "LFU"
 	// EvictionFIFO represents First In First Out eviction policy
 	EvictionFIFO = "FIFO"
+	// EvictionTinyLFU represents the W-TinyLFU admission and eviction policy
+	EvictionTinyLFU = "TinyLFU"
+	// EvictionARC represents the Adaptive Replacement Cache eviction policy
+	EvictionARC = "ARC"
+	// EvictionSIEVE represents the SIEVE eviction policy
+	EvictionSIEVE = "SIEVE"
+	// EvictionS3FIFO represents the S3-FIFO eviction policy
+	EvictionS3FIFO = "S3FIFO"
+	// EvictionGDSF represents the cost-aware Greedy-Dual-Size-Frequency eviction policy
+	EvictionGDSF = "GDSF"
+	// EvictionAllKeysLRU represents sampled eviction of the least recently used item
+	EvictionAllKeysLRU = "allkeys-lru"
+	// EvictionAllKeysLFU represents sampled eviction of the least frequently used item
+	EvictionAllKeysLFU = "allkeys-lfu"
+	// EvictionVolatileLRU represents sampled eviction of the least recently used item with a TTL
+	EvictionVolatileLRU = "volatile-lru"
+	// EvictionVolatileTTL represents sampled eviction of the item with a TTL closest to expiring
+	EvictionVolatileTTL = "volatile-ttl"
 )
 
 // Option defines a function type for configuring cache options
@@ -37,10 +56,20 @@ type Option func(*cacheOptions)
 
 // cacheOptions holds the configuration options for creating a cache
 type cacheOptions struct {
-	maxSize        int        // Maximum memory usage in bytes
-	evictionPolicy string     // Eviction policy
-	compressor     Compressor // Compression algorithm
-	compressSize   int        // Compression size threshold
+	maxSize         int                 // Maximum memory usage in bytes
+	evictionPolicy  string              // Eviction policy
+	evictionList    EvictionListFactory // Custom eviction list factory, overrides evictionPolicy
+	compressor      Compressor          // Compression algorithm
+	compressSize    int                 // Compression size threshold
+	cleanupInterval time.Duration       // Interval between background expiration sweeps (0 disables)
+	onEvict         RemovalFunc         // Callback invoked when items leave the cache
+	keyIndex        bool                // Whether shards maintain a sorted key index
+	loader          LoaderFunc          // Loader used to refresh items in the background
+	refreshAhead    float64             // Final fraction of an item's lifetime in which reads trigger a refresh
+	staleTTL        time.Duration       // Period after expiration during which items are served as stale
+	negativeTTL     time.Duration       // TTL of cached "not found" loader results
+	lfuDecay        time.Duration       // Half-life of LFU frequencies (0 disables decay)
+	evictionSamples int                 // Items inspected per eviction by the sampled policies
 }
 
 // WithMaxSize sets the maximum memory size for the cache
@@ -57,10 +86,22 @@ func WithCompressSize(size int) Option {
 	}
 }
 
-// WithEvictionPolicy sets the eviction policy for the cache
+// WithEvictionPolicy sets the eviction policy for the cache, either a built-in policy
+// or one added with RegisterEvictionPolicy
 func WithEvictionPolicy(policy string) Option {
 	return func(opts *cacheOptions) {
 		opts.evictionPolicy = policy
+		opts.evictionList = nil
+	}
+}
+
+// WithEvictionList sets a custom eviction policy for the cache. The factory is called
+// once per shard and must return a new list each time; lists implementing CapacityAware
+// are sized to the shard memory limit. Stats report the policy as "custom".
+func WithEvictionList(factory func() EvictionList) Option {
+	return func(opts *cacheOptions) {
+		opts.evictionPolicy = customEvictionPolicy
+		opts.evictionList = factory
 	}
 }
 
@@ -71,6 +112,96 @@ func WithCompressor(compressor Compressor) Option {
 	}
 }
 
+// WithCleanupInterval enables a background sweeper that removes expired items
+// from every shard at the given interval. A non-positive interval disables it,
+// leaving expired items to be removed lazily on access.
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(opts *cacheOptions) {
+		opts.cleanupInterval = interval
+	}
+}
+
+// WithOnEvict registers a callback invoked whenever an item leaves the cache,
+// together with the reason it was removed. The callback runs after the shard
+// lock has been released, so it may safely call back into the cache.
+func WithOnEvict(fn RemovalFunc) Option {
+	return func(opts *cacheOptions) {
+		opts.onEvict = fn
+	}
+}
+
+// WithKeyIndex makes every shard maintain a sorted index of its keys, so that
+// DeletePrefix and DeleteMatching only visit matching keys instead of scanning
+// the whole cache. The index costs one skip list node per key and slows down
+// inserts and removals slightly.
+func WithKeyIndex(enabled bool) Option {
+	return func(opts *cacheOptions) {
+		opts.keyIndex = enabled
+	}
+}
+
+// WithLoader registers the loader used to refresh items in the background, for
+// refresh-ahead and for values served from the stale window. Refreshed values are
+// stored with the TTL the item was originally stored with.
+func WithLoader(loader LoaderFunc) Option {
+	return func(opts *cacheOptions) {
+		opts.loader = loader
+	}
+}
+
+// WithRefreshAhead makes reads of an item in the final fraction of its lifetime
+// refresh it asynchronously while the current value keeps being served. For
+// example, 0.2 refreshes an item with a 10 minute TTL when it is read during its
+// last 2 minutes. A fraction outside (0, 1] disables refresh-ahead.
+func WithRefreshAhead(fraction float64) Option {
+	return func(opts *cacheOptions) {
+		if fraction <= 0 || fraction > 1 {
+			fraction = 0
+		}
+		opts.refreshAhead = fraction
+	}
+}
+
+// WithStaleTTL retains expired items for the given period, during which GetStale
+// and GetOrLoad return them flagged as stale while a refresh runs. Get treats
+// stale items as missing.
+func WithStaleTTL(ttl time.Duration) Option {
+	return func(opts *cacheOptions) {
+		opts.staleTTL = ttl
+	}
+}
+
+// WithNegativeTTL caches "not found" loader results for the given period. A loader
+// passed to GetOrLoad or WithLoader reports a missing key by returning ErrKeyNotFound;
+// until the negative TTL elapses, lookups of that key return ErrKeyNotFound without
+// invoking the loader and are counted as negative hits. A non-positive TTL disables
+// negative caching.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(opts *cacheOptions) {
+		opts.negativeTTL = ttl
+	}
+}
+
+// WithLFUDecay makes the frequencies of the LFU policy decay with the given half-life.
+// Every half-life, the frequency and AccessCount of each item are halved, so items
+// that were hot in the past can be evicted in favor of the current hot set. The
+// option only affects the LFU policy. A half-life of 0 or less disables decay.
+func WithLFUDecay(halfLife time.Duration) Option {
+	return func(opts *cacheOptions) {
+		opts.lfuDecay = halfLife
+	}
+}
+
+// WithEvictionSamples sets the number of items the sampled policies ("allkeys-lru",
+// "allkeys-lfu", "volatile-lru" and "volatile-ttl") inspect per eviction. More samples
+// approximate the exact policy more closely at a higher eviction cost. Values below 1
+// select DefaultEvictionSamples. Other policies ignore the option.
+func WithEvictionSamples(n int) Option {
+	return func(opts *cacheOptions) {
+		opts.evictionSamples = n
+	}
+}
+
 // Cache represents a thread-safe, in-memory cache with configurable eviction policies.
 // It uses a sharded architecture to reduce lock contention and improve concurrent performance.
 // The cache supports memory-based size limits, TTL expiration, and automatic data compression.
@@ -79,6 +210,7 @@ type Cache struct {
 	evictionPolicy string        // Eviction policy
 	shards         []*CacheShard // Cache shards
 	shardCount     int           // Number of cache shards
+	closeOnce      sync.Once     // Guards Close against repeated calls
 }
 
 // Stats holds comprehensive statistics for cache performance monitoring and analysis.
@@ -87,6 +219,12 @@ type Stats struct {
 	Hits           int    // Total number of successful cache hits
 	Misses         int    // Total number of cache misses
 	Evictions      int    // Total number of items evicted due to policies
+	Loads          int    // Total number of loader invocations made by GetOrLoad
+	LoadErrors     int    // Total number of loader invocations that returned an error
+	Expirations    int    // Total number of items removed because their TTL elapsed
+	NegativeHits   int    // Total number of lookups answered by a cached "not found" result
+	ExpiredBytes   int    // Total bytes reclaimed by removing expired items
+	EvictedBytes   int    // Total bytes reclaimed by policy evictions
 	CurrentCount   int    // Current number of items in cache
 	CurrentSize    int    // Current total memory usage in bytes
 	MaxSize        int    // Maximum allowed memory size in bytes
@@ -101,8 +239,18 @@ type Stats struct {
 //
 // Available options:
 //   - WithMaxSize(size int64): Set maximum memory usage in bytes (default: 100MB)
-//   - WithEvictionPolicy(policy string): Set eviction policy ("LRU", "LFU", or "FIFO") (default: "LRU")
+//   - WithEvictionPolicy(policy string): Set eviction policy ("LRU", "LFU", "FIFO", "TinyLFU", "ARC", "SIEVE", "S3FIFO", "GDSF", "allkeys-lru", "allkeys-lfu", "volatile-lru", "volatile-ttl" or a registered policy) (default: "LRU")
+//   - WithEvictionList(factory func() EvictionList): Use a custom eviction policy (default: none)
 //   - WithCompressor(compressor string): Set compression algorithm ("gzip", "zstd", "none") (default: "gzip")
+//   - WithCleanupInterval(interval time.Duration): Sweep expired items in the background (default: disabled)
+//   - WithOnEvict(fn RemovalFunc): Be notified when items leave the cache (default: none)
+//   - WithKeyIndex(enabled bool): Index keys for prefix and pattern deletion (default: false)
+//   - WithLoader(loader LoaderFunc): Loader used for background refreshes (default: none)
+//   - WithRefreshAhead(fraction float64): Refresh items read near the end of their TTL (default: disabled)
+//   - WithStaleTTL(ttl time.Duration): Serve expired items as stale while refreshing (default: disabled)
+//   - WithNegativeTTL(ttl time.Duration): Cache "not found" loader results (default: disabled)
+//   - WithLFUDecay(halfLife time.Duration): Halve LFU frequencies every half-life (default: disabled)
+//   - WithEvictionSamples(n int): Items inspected per eviction by the sampled policies (default: 5)
 //
 // Returns:
 //   - *Cache: A new cache instance ready for use
@@ -113,7 +261,54 @@ type Stats struct {
 //
 // The cache automatically determines the optimal number of shards based on the system's CPU count
 // to maximize concurrent performance. Default values are used for any unspecified options.
+// Unknown eviction policies fall back to LRU; use NewCacheE to have them reported instead.
 func NewCache(opts ...Option) *Cache {
+	options := newCacheOptions(opts)
+
+	// Validate and normalize eviction policy
+	factory := options.evictionList
+	if factory == nil {
+		var err error
+		if factory, err = lookupEvictionPolicy(options.evictionPolicy); err != nil {
+			options.evictionPolicy = EvictionLRU // Default to LRU for invalid policies
+			factory, _ = lookupEvictionPolicy(options.evictionPolicy)
+		}
+	}
+
+	return newCache(options, factory)
+}
+
+// NewCacheE creates a new cache instance like NewCache, but reports invalid options
+// instead of falling back to defaults.
+//
+// Parameters:
+//   - opts: Variadic functional options to configure the cache, see NewCache
+//
+// Returns:
+//   - *Cache: A new cache instance ready for use, nil on error
+//   - error: nil on success, ErrUnknownPolicy if the eviction policy is not registered
+func NewCacheE(opts ...Option) (*Cache, error) {
+	options := newCacheOptions(opts)
+
+	factory := options.evictionList
+	if factory == nil {
+		var err error
+		if factory, err = lookupEvictionPolicy(options.evictionPolicy); err != nil {
+			return nil, err
+		}
+	}
+
+	return newCache(options, factory), nil
+}
+
+// newCacheOptions applies opts on top of the default options.
+//
+// Parameters:
+//   - opts: Functional options to apply
+//
+// Returns:
+//   - *cacheOptions: The resulting configuration
+func newCacheOptions(opts []Option) *cacheOptions {
 	// Apply default options
 	options := &cacheOptions{
 		maxSize:        1024 * 1024 * 100, // Default: 100MB
@@ -127,12 +322,23 @@ func NewCache(opts ...Option) *Cache {
 		opt(options)
 	}
 
-	// Validate and normalize eviction policy
-	switch options.evictionPolicy {
-	case EvictionLRU, EvictionLFU, EvictionFIFO:
-		// Valid policies - keep as-is
-	default:
-		options.evictionPolicy = EvictionLRU // Default to LRU for invalid policies
+	return options
+}
+
+// newCache creates a cache from validated options.
+//
+// Parameters:
+//   - options: Cache configuration
+//   - factory: Function creating the eviction list of each shard
+//
+// Returns:
+//   - *Cache: A new cache instance ready for use
+func newCache(options *cacheOptions, factory EvictionListFactory) *Cache {
+	if options.lfuDecay > 0 && options.evictionList == nil && options.evictionPolicy == EvictionLFU {
+		factory = func() EvictionList { return NewLFUListWithDecay(options.lfuDecay) }
+	}
+	if mode, sampled := sampledPolicies[options.evictionPolicy]; sampled && options.evictionList == nil && options.evictionSamples > 0 {
+		factory = func() EvictionList { return NewSampledList(mode, options.evictionSamples) }
 	}
 
 	// Calculate optimal shard count based on system characteristics
@@ -153,7 +359,22 @@ func NewCache(opts ...Option) *Cache {
 	}
 
 	for i := 0; i < shardCount; i++ {
-		cache.shards[i] = NewCacheShard(shardMaxSize, options.evictionPolicy, options.compressor, options.compressSize)
+		cache.shards[i] = newCacheShard(shardMaxSize, options.evictionPolicy, factory, options.compressor, options.compressSize)
+		cache.shards[i].onEvict = options.onEvict
+		cache.shards[i].loader = options.loader
+		cache.shards[i].refreshAhead = options.refreshAhead
+		if options.staleTTL > 0 {
+			cache.shards[i].staleTTL = options.staleTTL
+		}
+		if options.negativeTTL > 0 {
+			cache.shards[i].negativeTTL = options.negativeTTL
+		}
+		if options.keyIndex {
+			cache.shards[i].keys = newKeyIndex()
+		}
+		if options.cleanupInterval > 0 {
+			cache.shards[i].startJanitor(options.cleanupInterval)
+		}
 	}
 
 	return cache
@@ -176,6 +397,52 @@ func (c *Cache) Set(key string, value []byte, ttl time.Duration) error {
 	return shard.Set(key, value, ttl)
 }
 
+// SetOptions holds the per-item settings accepted by SetWithOptions.
+type SetOptions struct {
+	TTL         time.Duration // Absolute time to live (0 for no expiration)
+	IdleTimeout time.Duration // Expire after this period without a successful Get (0 to disable)
+	Tags        []string      // Tags the item belongs to, see InvalidateTag
+	Cost        float64       // Cost of recomputing the value, weighed by the GDSF policy (0 for the default of 1)
+}
+
+// SetWithOptions stores a key-value pair with absolute and sliding expiration and tags.
+//
+// Parameters:
+//   - key: The cache key (must be non-empty string)
+//   - value: The value to store
+//   - opts: Expiration, tag and cost settings for the item
+//
+// Returns:
+//   - error: nil on success, error if operation fails
+//
+// When both TTL and IdleTimeout are set, the item expires at whichever comes first:
+// TTL after it was stored, or IdleTimeout after the last successful Get. For example,
+// a session that expires after 30 minutes of inactivity but never lives longer than
+// 12 hours:
+//
+//	cache.SetWithOptions("session:42", data, SetOptions{TTL: 12 * time.Hour, IdleTimeout: 30 * time.Minute})
+//
+// Cost tells the GDSF policy how expensive the value is to recompute; items with a
+// higher cost per byte are kept longer. Other policies ignore it.
+func (c *Cache) SetWithOptions(key string, value []byte, opts SetOptions) error {
+	shard := c.getShard(key)
+	item := shard.newItem(key, value, opts.TTL, time.Now())
+	if opts.IdleTimeout > 0 {
+		item.IdleTimeout = opts.IdleTimeout
+	}
+	item.setTags(opts.Tags)
+	if opts.Cost > 0 {
+		item.Cost = opts.Cost
+	}
+
+	shard.mu.Lock()
+	defer shard.unlock()
+
+	shard.storeLocked(item)
+
+	return nil
+}
+
 // Get retrieves a value from the cache by key.
 //
 // Parameters:
@@ -213,6 +480,18 @@ func (c *Cache) Clear() {
 	}
 }
 
+// Close stops the background goroutines owned by the cache.
+//
+// The cache remains usable after Close, but expired items are then only removed
+// lazily on access. Calling Close more than once is safe.
+func (c *Cache) Close() {
+	c.closeOnce.Do(func() {
+		for _, shard := range c.shards {
+			shard.stopJanitor()
+		}
+	})
+}
+
 // Stats returns a snapshot of current cache statistics.
 //
 // Returns:
@@ -223,6 +502,8 @@ func (c *Cache) Clear() {
 // state across all cache shards.
 func (c *Cache) Stats() Stats {
 	var totalHits, totalMisses, totalEvictions int
+	var totalLoads, totalLoadErrors, totalExpirations, totalNegativeHits int
+	var totalExpiredBytes, totalEvictedBytes int
 	var totalCurrentCount, totalCurrentSize int
 
 	// Aggregate statistics from all shards
@@ -231,6 +512,12 @@ func (c *Cache) Stats() Stats {
 		totalHits += shardStats.Hits
 		totalMisses += shardStats.Misses
 		totalEvictions += shardStats.Evictions
+		totalLoads += shardStats.Loads
+		totalLoadErrors += shardStats.LoadErrors
+		totalExpirations += shardStats.Expirations
+		totalNegativeHits += shardStats.NegativeHits
+		totalExpiredBytes += shardStats.ExpiredBytes
+		totalEvictedBytes += shardStats.EvictedBytes
 		totalCurrentCount += shardStats.CurrentCount
 		totalCurrentSize += shardStats.CurrentSize
 	}
@@ -240,6 +527,12 @@ func (c *Cache) Stats() Stats {
 		Hits:           totalHits,
 		Misses:         totalMisses,
 		Evictions:      totalEvictions,
+		Loads:          totalLoads,
+		LoadErrors:     totalLoadErrors,
+		Expirations:    totalExpirations,
+		NegativeHits:   totalNegativeHits,
+		ExpiredBytes:   totalExpiredBytes,
+		EvictedBytes:   totalEvictedBytes,
 		CurrentCount:   totalCurrentCount,
 		CurrentSize:    totalCurrentSize,
 		MaxSize:        c.maxSize,
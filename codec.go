@@ -0,0 +1,82 @@
+package tscache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec defines how values of type V are converted to and from the bytes stored in the cache.
+// Codecs are used by TypedCache and must be safe for concurrent use.
+type Codec[V any] interface {
+	// Encode serializes a value into bytes
+	Encode(value V) ([]byte, error)
+	// Decode deserializes bytes back into a value
+	Decode(data []byte) (V, error)
+}
+
+// JSONCodec implements the Codec interface using encoding/json.
+// It works with any JSON-serializable type and produces human-readable payloads.
+type JSONCodec[V any] struct{}
+
+// NewJSONCodec creates a new JSON codec for values of type V.
+func NewJSONCodec[V any]() *JSONCodec[V] {
+	return &JSONCodec[V]{}
+}
+
+// Encode serializes the value to JSON.
+func (c *JSONCodec[V]) Encode(value V) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Decode deserializes a JSON document into a value of type V.
+func (c *JSONCodec[V]) Decode(data []byte) (V, error) {
+	var value V
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// GobCodec implements the Codec interface using encoding/gob.
+// It handles Go-specific types that JSON cannot represent faithfully, at the cost
+// of embedding type information in every encoded value.
+type GobCodec[V any] struct{}
+
+// NewGobCodec creates a new gob codec for values of type V.
+func NewGobCodec[V any]() *GobCodec[V] {
+	return &GobCodec[V]{}
+}
+
+// Encode serializes the value with a fresh gob encoder.
+func (c *GobCodec[V]) Encode(value V) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode deserializes gob-encoded data into a value of type V.
+func (c *GobCodec[V]) Decode(data []byte) (V, error) {
+	var value V
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return value, err
+}
+
+// BytesCodec implements the Codec interface for raw byte slices.
+// Values are stored as-is without any serialization overhead.
+type BytesCodec struct{}
+
+// NewBytesCodec creates a new raw-bytes codec.
+func NewBytesCodec() *BytesCodec {
+	return &BytesCodec{}
+}
+
+// Encode returns the value unchanged.
+func (c *BytesCodec) Encode(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+// Decode returns the data unchanged.
+func (c *BytesCodec) Decode(data []byte) ([]byte, error) {
+	return data, nil
+}
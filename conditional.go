@@ -0,0 +1,126 @@
+package tscache
+
+import (
+	"time"
+)
+
+// Add stores a value only if the key is not already present.
+//
+// Parameters:
+//   - key: The cache key
+//   - value: The value to store
+//   - ttl: Time to live duration (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success, ErrKeyExists if a live item is stored under key
+//
+// Expired items are treated as absent.
+func (c *Cache) Add(key string, value []byte, ttl time.Duration) error {
+	shard := c.getShard(key)
+	return shard.setIf(key, value, ttl, func(item *CacheItem, exists bool) error {
+		if exists {
+			return ErrKeyExists
+		}
+		return nil
+	})
+}
+
+// Replace stores a value only if the key is already present.
+//
+// Parameters:
+//   - key: The cache key
+//   - value: The value to store
+//   - ttl: Time to live duration (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success, ErrKeyNotFound if no live item is stored under key
+func (c *Cache) Replace(key string, value []byte, ttl time.Duration) error {
+	shard := c.getShard(key)
+	return shard.setIf(key, value, ttl, func(item *CacheItem, exists bool) error {
+		if !exists {
+			return ErrKeyNotFound
+		}
+		return nil
+	})
+}
+
+// GetWithVersion retrieves a value together with its version.
+//
+// Parameters:
+//   - key: The cache key to lookup
+//
+// Returns:
+//   - []byte: The cached value (nil if not found)
+//   - uint64: Version of the stored item, to be passed to CompareAndSwap
+//   - error: nil if found, ErrKeyNotFound if the key doesn't exist or has expired
+//
+// Every write to a key assigns it a new, strictly greater version, so the version
+// identifies exactly the value that was read.
+func (c *Cache) GetWithVersion(key string) ([]byte, uint64, error) {
+	shard := c.getShard(key)
+
+	item := shard.access(key)
+	if item == nil {
+		return nil, 0, ErrKeyNotFound
+	}
+
+	value, err := shard.decode(item)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, item.Version, nil
+}
+
+// CompareAndSwap stores a value only if the key still holds the given version.
+//
+// Parameters:
+//   - key: The cache key
+//   - version: Version previously returned by GetWithVersion
+//   - value: The value to store
+//   - ttl: Time to live duration (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success, ErrKeyNotFound if the key is missing or expired,
+//     ErrVersionMismatch if another writer updated the key since it was read
+func (c *Cache) CompareAndSwap(key string, version uint64, value []byte, ttl time.Duration) error {
+	shard := c.getShard(key)
+	return shard.setIf(key, value, ttl, func(item *CacheItem, exists bool) error {
+		if !exists {
+			return ErrKeyNotFound
+		}
+		if item.Version != version {
+			return ErrVersionMismatch
+		}
+		return nil
+	})
+}
+
+// setIf stores a value if the condition accepts the item currently stored under key.
+//
+// Parameters:
+//   - key: Cache key
+//   - value: Value to store
+//   - ttl: Time to live (0 for no expiration)
+//   - cond: Checks the current item (nil and false if absent) and returns an error to abort
+//
+// Returns:
+//   - error: nil on success, the condition's error otherwise
+//
+// The value is compressed before the lock is taken; the condition and the write
+// happen atomically under the shard lock.
+func (s *CacheShard) setIf(key string, value []byte, ttl time.Duration, cond func(item *CacheItem, exists bool) error) error {
+	now := time.Now()
+	item := s.newItem(key, value, ttl, now)
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	current, exists := s.lookupLocked(key, now)
+	if err := cond(current, exists); err != nil {
+		return err
+	}
+
+	s.storeLocked(item)
+
+	return nil
+}
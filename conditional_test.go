@@ -0,0 +1,106 @@
+package tscache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheAdd(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	if err := cache.Add("key", toBytes("first"), 0); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := cache.Add("key", toBytes("second"), 0); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Add on existing key error = %v, want ErrKeyExists", err)
+	}
+
+	value, _ := cache.Get("key")
+	if string(value) != "first" {
+		t.Errorf("value = %s, want first", string(value))
+	}
+
+	// 过期的key视为不存在
+	cache.Set("expired", toBytes("old"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	if err := cache.Add("expired", toBytes("new"), 0); err != nil {
+		t.Errorf("Add on expired key failed: %v", err)
+	}
+}
+
+func TestCacheReplace(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	if err := cache.Replace("key", toBytes("value"), 0); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Replace on missing key error = %v, want ErrKeyNotFound", err)
+	}
+
+	cache.Set("key", toBytes("old"), 0)
+	if err := cache.Replace("key", toBytes("new"), 0); err != nil {
+		t.Errorf("Replace failed: %v", err)
+	}
+
+	value, _ := cache.Get("key")
+	if string(value) != "new" {
+		t.Errorf("value = %s, want new", string(value))
+	}
+}
+
+func TestCacheCompareAndSwap(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.Set("key", toBytes("v1"), 0)
+	_, version, err := cache.GetWithVersion("key")
+	if err != nil {
+		t.Fatalf("GetWithVersion failed: %v", err)
+	}
+
+	// 版本匹配时写入成功，并产生新的版本
+	if err := cache.CompareAndSwap("key", version, toBytes("v2"), 0); err != nil {
+		t.Fatalf("CompareAndSwap failed: %v", err)
+	}
+	value, newVersion, _ := cache.GetWithVersion("key")
+	if string(value) != "v2" || newVersion <= version {
+		t.Errorf("after CAS value = %s, version = %d (old %d)", string(value), newVersion, version)
+	}
+
+	// 旧版本写入失败
+	if err := cache.CompareAndSwap("key", version, toBytes("v3"), 0); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("stale CAS error = %v, want ErrVersionMismatch", err)
+	}
+
+	if err := cache.CompareAndSwap("missing", 1, toBytes("v"), 0); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("CAS on missing key error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCacheCompareAndSwapConcurrent(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+	cache.Set("balance", toBytes("0"), 0)
+
+	// 使用CAS循环实现并发安全的更新
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				for {
+					value, version, _ := cache.GetWithVersion("balance")
+					next := append(append([]byte{}, value...), 'x')
+					if cache.CompareAndSwap("balance", version, next, 0) == nil {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, _ := cache.Get("balance")
+	if len(value) != 1+500 {
+		t.Errorf("len(value) = %d, want 501", len(value))
+	}
+}
@@ -0,0 +1,101 @@
+package tscache
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Incr atomically increments the integer stored under key by one.
+// See IncrBy for details.
+func (c *Cache) Incr(key string, ttl time.Duration) (int64, error) {
+	return c.IncrBy(key, 1, ttl)
+}
+
+// Decr atomically decrements the integer stored under key by one.
+// See IncrBy for details.
+func (c *Cache) Decr(key string, ttl time.Duration) (int64, error) {
+	return c.IncrBy(key, -1, ttl)
+}
+
+// DecrBy atomically decrements the integer stored under key by delta.
+// See IncrBy for details.
+func (c *Cache) DecrBy(key string, delta int64, ttl time.Duration) (int64, error) {
+	if delta == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return c.IncrBy(key, -delta, ttl)
+}
+
+// IncrBy atomically adds delta to the integer stored under key.
+//
+// Parameters:
+//   - key: The cache key of the counter
+//   - delta: Amount to add (may be negative)
+//   - ttl: Time to live used only when the counter is created (0 for no expiration)
+//
+// Returns:
+//   - int64: The counter value after the update
+//   - error: nil on success, ErrNotNumber if the stored value is not a decimal integer,
+//     ErrOverflow if the result does not fit in an int64
+//
+// Counters are stored as decimal strings, so they can also be read with Get. A missing
+// or expired key is created with the value delta and the given TTL; an existing
+// counter keeps its original expiration time. The read-modify-write happens under the
+// shard lock, so concurrent updates never lose increments.
+func (c *Cache) IncrBy(key string, delta int64, ttl time.Duration) (int64, error) {
+	shard := c.getShard(key)
+	return shard.incrBy(key, delta, ttl)
+}
+
+// incrBy adds delta to the counter stored under key in this shard.
+//
+// Parameters:
+//   - key: Cache key of the counter
+//   - delta: Amount to add
+//   - ttl: Time to live for newly created counters
+//
+// Returns:
+//   - int64: The updated counter value
+//   - error: ErrNotNumber or ErrOverflow on failure
+func (s *CacheShard) incrBy(key string, delta int64, ttl time.Duration) (int64, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	var current int64
+	item, exists := s.lookupLocked(key, now)
+	if exists {
+		if item.Object != nil {
+			return 0, ErrNotNumber
+		}
+
+		raw, err := s.decode(item)
+		if err != nil {
+			return 0, err
+		}
+
+		current, err = strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return 0, ErrNotNumber
+		}
+	}
+
+	if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+		return 0, ErrOverflow
+	}
+	next := current + delta
+
+	updated := s.newItem(key, strconv.AppendInt(nil, next, 10), ttl, now)
+	if exists {
+		updated.ExpireAt = item.ExpireAt
+		updated.IdleTimeout = item.IdleTimeout
+		updated.Cost = item.Cost
+		updated.ttl = item.ttl
+		updated.setTags(item.Tags)
+	}
+	s.storeLocked(updated)
+
+	return next, nil
+}
@@ -0,0 +1,97 @@
+package tscache
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheIncrBy(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	// 不存在的key会被创建
+	value, err := cache.IncrBy("counter", 5, 0)
+	if err != nil || value != 5 {
+		t.Fatalf("IncrBy = %d, %v, want 5", value, err)
+	}
+
+	value, _ = cache.Incr("counter", 0)
+	if value != 6 {
+		t.Errorf("Incr = %d, want 6", value)
+	}
+	value, _ = cache.DecrBy("counter", 10, 0)
+	if value != -4 {
+		t.Errorf("DecrBy = %d, want -4", value)
+	}
+	value, _ = cache.Decr("counter", 0)
+	if value != -5 {
+		t.Errorf("Decr = %d, want -5", value)
+	}
+
+	// 计数器以十进制字符串存储
+	raw, err := cache.Get("counter")
+	if err != nil || string(raw) != "-5" {
+		t.Errorf("Get counter = %s, %v, want -5", string(raw), err)
+	}
+}
+
+func TestCacheIncrByErrors(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.Set("text", toBytes("hello"), 0)
+	if _, err := cache.Incr("text", 0); !errors.Is(err, ErrNotNumber) {
+		t.Errorf("Incr on text error = %v, want ErrNotNumber", err)
+	}
+
+	cache.Set("max", toBytes("9223372036854775807"), 0)
+	if _, err := cache.Incr("max", 0); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Incr on max error = %v, want ErrOverflow", err)
+	}
+	if _, err := cache.DecrBy("min", math.MinInt64, 0); !errors.Is(err, ErrOverflow) {
+		t.Errorf("DecrBy MinInt64 error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestCacheIncrByPreservesTTL(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.Incr("window", 30*time.Millisecond)
+	time.Sleep(15 * time.Millisecond)
+
+	// 更新时保留原有的过期时间，忽略新的TTL
+	cache.Incr("window", time.Hour)
+	time.Sleep(25 * time.Millisecond)
+
+	if _, err := cache.Get("window"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("counter should have expired with its original TTL, got %v", err)
+	}
+
+	// 过期后重新创建
+	value, _ := cache.Incr("window", time.Hour)
+	if value != 1 {
+		t.Errorf("Incr after expiry = %d, want 1", value)
+	}
+}
+
+func TestCacheIncrByConcurrent(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cache.Incr("hits", 0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, _ := cache.IncrBy("hits", 0, 0)
+	if value != 2000 {
+		t.Errorf("counter = %d, want 2000", value)
+	}
+}
@@ -0,0 +1,192 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+// newDecayingLFU 创建使用手动时钟的衰减LFU列表
+func newDecayingLFU(halfLife time.Duration) (*LFUList, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	lfu := NewLFUListWithDecay(halfLife)
+	lfu.now = clock.Now
+	lfu.nextDecay = clock.now.Add(halfLife)
+	return lfu, clock
+}
+
+func TestLFUDecay(t *testing.T) {
+	lfu, clock := newDecayingLFU(time.Minute)
+
+	items := map[string]*CacheItem{
+		"a": {Key: "a", AccessCount: 40},
+		"b": {Key: "b", AccessCount: 9},
+		"c": {Key: "c", AccessCount: 8},
+		"d": {Key: "d", AccessCount: 1},
+	}
+	for _, key := range []string{"a", "b", "c", "d"} {
+		lfu.Add(key, items[key])
+	}
+
+	// 半衰期未到时频率不变
+	clock.Advance(59 * time.Second)
+	lfu.Update("d", items["d"])
+	if items["a"].AccessCount != 40 {
+		t.Errorf("AccessCount before half-life = %d, want 40", items["a"].AccessCount)
+	}
+
+	// 半衰期到达后所有频率减半，AccessCount同步减半
+	clock.Advance(time.Second)
+	lfu.Update("d", items["d"])
+	tests := []struct {
+		key         string
+		frequency   int
+		accessCount int
+	}{
+		{"a", 20, 20},
+		{"b", 4, 4},
+		{"c", 4, 4},
+		{"d", 1, 0},
+	}
+	for _, tt := range tests {
+		if node := lfu.nodes[tt.key]; node.Frequency != tt.frequency || items[tt.key].AccessCount != tt.accessCount {
+			t.Errorf("%s: frequency = %d, AccessCount = %d; want %d, %d",
+				tt.key, node.Frequency, items[tt.key].AccessCount, tt.frequency, tt.accessCount)
+		}
+	}
+
+	// 减半后频率相同的桶被合并
+	if lfu.buckets.Len() != 3 {
+		t.Errorf("bucket count = %d, want 3", lfu.buckets.Len())
+	}
+
+	// 多个半衰期一次性应用，合并桶中原频率较高的数据排在前面
+	clock.Advance(3 * time.Minute)
+	for _, want := range []string{"d", "c", "b", "a"} {
+		if removed := lfu.RemoveLeast(); removed != want {
+			t.Errorf("RemoveLeast() = %q, want %q", removed, want)
+		}
+	}
+	if items["a"].AccessCount != 2 {
+		t.Errorf("AccessCount after three more half-lives = %d, want 2", items["a"].AccessCount)
+	}
+
+	// 下一次衰减按原有节奏进行
+	if want := time.Unix(0, 0).Add(5 * time.Minute); !lfu.nextDecay.Equal(want) {
+		t.Errorf("next decay at %v, want %v", lfu.nextDecay, want)
+	}
+}
+
+// simulateLFUShift 模拟热点数据迁移：旧热点被大量访问后不再访问，新热点开始被访问
+// 返回迁移后仍驻留的新热点数量
+func simulateLFUShift(lfu *LFUList, clock *fakeClock) int {
+	const capacity = 100
+	items := make(map[string]*CacheItem)
+
+	access := func(key string) {
+		if item, exists := items[key]; exists {
+			item.AccessCount++
+			lfu.Update(key, item)
+			return
+		}
+
+		item := &CacheItem{Key: key}
+		items[key] = item
+		lfu.Add(key, item)
+		for len(items) > capacity {
+			delete(items, lfu.RemoveLeast())
+		}
+	}
+
+	for round := 0; round < 50; round++ {
+		for i := 0; i < 80; i++ {
+			access(fmt.Sprintf("old%d", i))
+		}
+	}
+
+	for round := 0; round < 20; round++ {
+		if clock != nil {
+			clock.Advance(time.Minute)
+		}
+		for i := 0; i < 80; i++ {
+			access(fmt.Sprintf("new%d", i))
+		}
+	}
+
+	resident := 0
+	for i := 0; i < 80; i++ {
+		if _, exists := items[fmt.Sprintf("new%d", i)]; exists {
+			resident++
+		}
+	}
+	return resident
+}
+
+func TestLFUDecayWorkloadShift(t *testing.T) {
+	// 不衰减时旧热点的高频率使其无法被淘汰，新热点互相挤占
+	static := simulateLFUShift(NewLFUList(), nil)
+
+	lfu, clock := newDecayingLFU(time.Minute)
+	decayed := simulateLFUShift(lfu, clock)
+
+	t.Logf("new hot keys resident: static %d, decayed %d", static, decayed)
+	if static > 30 {
+		t.Errorf("without decay %d new hot keys resident, want at most 30", static)
+	}
+	if decayed < 75 {
+		t.Errorf("with decay %d new hot keys resident, want at least 75", decayed)
+	}
+}
+
+func TestWithLFUDecay(t *testing.T) {
+	cache := NewCache(WithEvictionPolicy(EvictionLFU), WithLFUDecay(time.Minute))
+	for _, shard := range cache.shards {
+		if lfu := shard.evictionList.(*LFUList); lfu.halfLife != time.Minute {
+			t.Errorf("LFU half-life = %v, want 1m", lfu.halfLife)
+		}
+	}
+
+	// 衰减选项只影响LFU策略
+	cache = NewCache(WithEvictionPolicy(EvictionLRU), WithLFUDecay(time.Minute))
+	if _, ok := cache.shards[0].evictionList.(*LRUList); !ok {
+		t.Errorf("eviction list is %T, want *LRUList", cache.shards[0].evictionList)
+	}
+	cache = NewCache(WithEvictionPolicy(EvictionLFU))
+	if lfu := cache.shards[0].evictionList.(*LFUList); lfu.halfLife != 0 {
+		t.Errorf("LFU half-life without WithLFUDecay = %v, want 0", lfu.halfLife)
+	}
+
+	// 缓存中的访问计数随时间衰减
+	cache = NewCache(WithEvictionPolicy(EvictionLFU), WithLFUDecay(time.Minute))
+	shard := cache.getShard("key")
+	lfu := shard.evictionList.(*LFUList)
+	clock := &fakeClock{now: time.Now()}
+	lfu.now = clock.Now
+
+	cache.Set("key", toBytes("value"), 0)
+	for i := 0; i < 8; i++ {
+		cache.Get("key")
+	}
+	clock.Advance(2 * time.Minute)
+	cache.Get("key")
+
+	cache.Range(func(key string, _ []byte, meta ItemInfo) bool {
+		if meta.AccessCount != 2 {
+			t.Errorf("AccessCount after two half-lives = %d, want 2", meta.AccessCount)
+		}
+		return true
+	})
+}
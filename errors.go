@@ -3,5 +3,12 @@ package tscache
 import "errors"
 
 var (
-	ErrKeyNotFound = errors.New("key not found")
+	ErrKeyNotFound     = errors.New("key not found")
+	ErrNotNumber       = errors.New("value is not an integer")
+	ErrOverflow        = errors.New("increment or decrement would overflow")
+	ErrKeyExists       = errors.New("key already exists")
+	ErrVersionMismatch = errors.New("version mismatch")
+	ErrBadPattern      = errors.New("malformed glob pattern")
+	ErrUnknownPolicy   = errors.New("unknown eviction policy")
+	ErrLoaderPanic     = errors.New("loader panicked")
 )
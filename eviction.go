@@ -146,12 +146,19 @@ func (lru *LRUList) Clear() {
 }
 
 // LFUNode represents a node in the LFU (Least Frequently Used) data structure.
-// Each node tracks access frequency and timing information for eviction decisions.
+// Each node sits in the bucket for its access frequency.
 type LFUNode struct {
-	Key       string     // Cache key for this node
-	Item      *CacheItem // Reference to the actual cache item
-	Frequency int        // Access frequency counter
-	LastUsed  time.Time  // Timestamp of last access (for tie-breaking)
+	Key       string        // Cache key for this node
+	Item      *CacheItem    // Reference to the actual cache item
+	Frequency int           // Access frequency counter
+	bucket    *list.Element // Element of the bucket list holding the node
+	element   *list.Element // Element of the node within its bucket
+}
+
+// lfuBucket groups the nodes sharing an access frequency.
+type lfuBucket struct {
+	frequency int        // Access frequency of every node in the bucket
+	nodes     *list.List // Nodes in LRU order (front = most recently used)
 }
 
 // LFUList implements the Least Frequently Used eviction policy.
@@ -159,17 +166,32 @@ type LFUNode struct {
 // items being evicted first. For items with equal frequency, the least recently
 // used item is evicted (LFU with LRU tie-breaking).
 //
+// Frequencies are kept in a list of buckets sorted by ascending frequency, and
+// every bucket keeps its nodes in LRU order. Nodes remember their list elements,
+// so no operation has to search a bucket, and the eviction victim is always the
+// back of the first bucket.
+//
+// Lists created with NewLFUListWithDecay age their frequencies: once per half-life
+// every frequency, and the AccessCount of every tracked item, is halved, so items
+// that are no longer accessed eventually lose out to the current hot set.
+//
 // Time Complexity:
-//   - Add: O(1)
-//   - Remove: O(1) for deletion, O(f) for frequency list cleanup where f is frequency count
-//   - Update: O(1)
-//   - RemoveLeast: O(n) where n is the number of items at minimum frequency
+//   - Add: O(1) for a new item with an AccessCount of at most 1; an item carrying a
+//     higher count walks the buckets up to its frequency
+//   - Replace: O(1)
+//   - Remove: O(1) with hash map lookup
+//   - Update: O(1) when the frequency grows by one; a larger change walks the
+//     buckets between the old and the new frequency
+//   - RemoveLeast: O(1)
+//   - Decay: O(n), at most once per half-life
 //
 // Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
 type LFUList struct {
-	nodes       map[string]*LFUNode // Hash map for O(1) key-to-node lookup
-	frequencies map[int]*list.List  // Frequency buckets (frequency -> list of nodes)
-	minFreq     int                 // Current minimum frequency for quick eviction
+	nodes     map[string]*LFUNode // Hash map for O(1) key-to-node lookup
+	buckets   *list.List          // Non-empty frequency buckets in ascending frequency order
+	halfLife  time.Duration       // Period after which frequencies are halved (0 disables decay)
+	nextDecay time.Time           // Time of the next halving
+	now       func() time.Time    // Clock used to schedule decay
 }
 
 // NewLFUList creates a new LFU eviction list.
@@ -181,12 +203,31 @@ type LFUList struct {
 // lists for each frequency level to enable efficient eviction.
 func NewLFUList() *LFUList {
 	return &LFUList{
-		nodes:       make(map[string]*LFUNode),
-		frequencies: make(map[int]*list.List),
-		minFreq:     1,
+		nodes:   make(map[string]*LFUNode),
+		buckets: list.New(),
 	}
 }
 
+// NewLFUListWithDecay creates a new LFU eviction list whose frequencies decay over time.
+//
+// Parameters:
+//   - halfLife: Period after which all frequencies are halved (0 or less disables decay)
+//
+// Returns:
+//   - *LFUList: A new LFU list ready for use
+//
+// Decay is applied lazily by the first operation after each half-life has elapsed.
+// Several elapsed half-lives are applied at once.
+func NewLFUListWithDecay(halfLife time.Duration) *LFUList {
+	lfu := NewLFUList()
+	if halfLife > 0 {
+		lfu.halfLife = halfLife
+		lfu.now = time.Now
+		lfu.nextDecay = lfu.now().Add(halfLife)
+	}
+	return lfu
+}
+
 // Add inserts a new item or updates an existing item's frequency in the LFU list.
 //
 // Parameters:
@@ -196,54 +237,43 @@ func NewLFUList() *LFUList {
 // New items start with frequency based on their access count. Existing items have
 // their frequency updated and are moved to the appropriate frequency bucket.
 func (lfu *LFUList) Add(key string, item *CacheItem) {
-	now := time.Now()
+	lfu.maybeDecay()
 
 	if node, exists := lfu.nodes[key]; exists {
-		// Update existing node
-		oldFreq := node.Frequency
-		newFreq := item.AccessCount
-
-		// Only move if frequency actually changed
-		if oldFreq != newFreq {
-			// Remove from old frequency bucket
-			lfu.removeFromFrequency(node, oldFreq)
-
-			// Update node data
-			node.Frequency = newFreq
-			node.LastUsed = now
-
-			// Add to new frequency bucket
-			lfu.addToFrequency(node, newFreq)
-
-			// Update minimum frequency if necessary
-			lfu.updateMinFreq()
-		} else {
-			// Just update the item and timestamp
-			node.Item = item
-			node.LastUsed = now
-		}
-	} else {
-		// Create new node with frequency from item's access count
-		frequency := item.AccessCount
-		if frequency == 0 {
-			frequency = 1 // Minimum frequency for new items
-		}
-
-		node := &LFUNode{
-			Key:       key,
-			Item:      item,
-			Frequency: frequency,
-			LastUsed:  now,
-		}
+		node.Item = item
+		lfu.move(node, max(item.AccessCount, 1))
+		return
+	}
 
-		lfu.nodes[key] = node
-		lfu.addToFrequency(node, frequency)
+	node := &LFUNode{
+		Key:       key,
+		Item:      item,
+		Frequency: max(item.AccessCount, 1), // Minimum frequency for new items
+	}
+	lfu.nodes[key] = node
+	lfu.insert(node, lfu.bucketFor(node.Frequency, nil))
+}
 
-		// Update minimum frequency
-		if frequency < lfu.minFreq || lfu.isEmpty(lfu.minFreq) {
-			lfu.minFreq = frequency
-		}
+// Replace swaps the item tracked for key, keeping its node and frequency bucket.
+//
+// Parameters:
+//   - key: Cache key of the replaced item
+//   - item: Replacement cache item
+//
+// The shard calls Replace when a key is overwritten; the replacement carries the
+// access count of the item it replaces, so the node stays in its bucket and only
+// becomes its most recently used entry. An untracked key is added.
+func (lfu *LFUList) Replace(key string, item *CacheItem) {
+	lfu.maybeDecay()
+
+	node, exists := lfu.nodes[key]
+	if !exists {
+		lfu.Add(key, item)
+		return
 	}
+
+	node.Item = item
+	lfu.move(node, max(item.AccessCount, 1))
 }
 
 // Remove deletes an item from the LFU list.
@@ -254,44 +284,25 @@ func (lfu *LFUList) Add(key string, item *CacheItem) {
 // The item is removed from both the node map and its frequency bucket.
 func (lfu *LFUList) Remove(key string) {
 	if node, exists := lfu.nodes[key]; exists {
-		lfu.removeFromFrequency(node, node.Frequency)
+		lfu.unlink(node)
 		delete(lfu.nodes, key)
-		lfu.updateMinFreq()
 	}
 }
 
-// Update increments an item's frequency and moves it to the appropriate bucket.
+// Update moves an item to the bucket matching its access count.
 //
 // Parameters:
 //   - key: Cache key to update
 //   - item: Updated cache item
 //
 // This method is called when an item is accessed to update its frequency count.
+// The item becomes the most recently used of its bucket.
 func (lfu *LFUList) Update(key string, item *CacheItem) {
-	if node, exists := lfu.nodes[key]; exists {
-		oldFreq := node.Frequency
-		newFreq := item.AccessCount
-
-		// Only move if frequency actually changed
-		if oldFreq != newFreq {
-			// Remove from old frequency bucket
-			lfu.removeFromFrequency(node, oldFreq)
-
-			// Update node
-			node.Item = item
-			node.Frequency = newFreq
-			node.LastUsed = time.Now()
-
-			// Add to new frequency bucket
-			lfu.addToFrequency(node, newFreq)
+	lfu.maybeDecay()
 
-			// Update minimum frequency
-			lfu.updateMinFreq()
-		} else {
-			// Just update the item and timestamp
-			node.Item = item
-			node.LastUsed = time.Now()
-		}
+	if node, exists := lfu.nodes[key]; exists {
+		node.Item = item
+		lfu.move(node, max(item.AccessCount, 1))
 	}
 }
 
@@ -303,109 +314,146 @@ func (lfu *LFUList) Update(key string, item *CacheItem) {
 // If multiple items have the same minimum frequency, the least recently
 // used among them is evicted (LFU with LRU tie-breaking).
 func (lfu *LFUList) RemoveLeast() string {
-	if len(lfu.nodes) == 0 {
-		return ""
-	}
-
-	// Find the frequency list with minimum frequency
-	freqList, exists := lfu.frequencies[lfu.minFreq]
-	if !exists || freqList.Len() == 0 {
-		return "" // No items to evict
-	}
-
-	// Find the least recently used item among items with minimum frequency
-	var oldestElement *list.Element
-	var oldestTime time.Time = time.Now()
-
-	for element := freqList.Front(); element != nil; element = element.Next() {
-		node := element.Value.(*LFUNode)
-		if oldestElement == nil || node.LastUsed.Before(oldestTime) {
-			oldestElement = element
-			oldestTime = node.LastUsed
-		}
-	}
+	lfu.maybeDecay()
 
-	if oldestElement == nil {
+	front := lfu.buckets.Front()
+	if front == nil {
 		return ""
 	}
 
-	// Remove the selected node
-	node := oldestElement.Value.(*LFUNode)
-	freqList.Remove(oldestElement)
+	node := front.Value.(*lfuBucket).nodes.Back().Value.(*LFUNode)
+	lfu.unlink(node)
 	delete(lfu.nodes, node.Key)
 
-	// Update minimum frequency if this was the last item at minFreq
-	lfu.updateMinFreq()
-
 	return node.Key
 }
 
 // Clear removes all items from the LFU list and resets its state.
 func (lfu *LFUList) Clear() {
 	lfu.nodes = make(map[string]*LFUNode)
-	lfu.frequencies = make(map[int]*list.List)
-	lfu.minFreq = 1
+	lfu.buckets = list.New()
+}
+
+// maybeDecay halves all frequencies once for every half-life elapsed since the last decay.
+func (lfu *LFUList) maybeDecay() {
+	if lfu.halfLife <= 0 {
+		return
+	}
+
+	now := lfu.now()
+	if now.Before(lfu.nextDecay) {
+		return
+	}
+
+	periods := now.Sub(lfu.nextDecay)/lfu.halfLife + 1
+	lfu.nextDecay = lfu.nextDecay.Add(periods * lfu.halfLife)
+	lfu.decay(uint(min(periods, 62)))
 }
 
-// addToFrequency adds a node to the appropriate frequency bucket.
+// decay divides every frequency by 2^shift, rebuilding the buckets.
 //
 // Parameters:
-//   - node: LFU node to add
-//   - frequency: Frequency level for the bucket
-func (lfu *LFUList) addToFrequency(node *LFUNode, frequency int) {
-	if lfu.frequencies[frequency] == nil {
-		lfu.frequencies[frequency] = list.New()
+//   - shift: Number of halvings to apply
+//
+// The AccessCount of each tracked item is divided as well, since the shard
+// reports it as the item's frequency on the next access. Buckets collapsing
+// into the same frequency are merged, with nodes of the formerly more frequent
+// bucket placed in front as the more recently used.
+func (lfu *LFUList) decay(shift uint) {
+	buckets := lfu.buckets
+	lfu.buckets = list.New()
+
+	for element := buckets.Front(); element != nil; element = element.Next() {
+		bucket := element.Value.(*lfuBucket)
+		frequency := max(bucket.frequency>>shift, 1)
+
+		dest := lfu.buckets.Back()
+		if dest == nil || dest.Value.(*lfuBucket).frequency != frequency {
+			dest = lfu.buckets.PushBack(&lfuBucket{frequency: frequency, nodes: list.New()})
+		}
+
+		// Walk from the back so the bucket's LRU order is kept
+		for nodeElement := bucket.nodes.Back(); nodeElement != nil; nodeElement = nodeElement.Prev() {
+			node := nodeElement.Value.(*LFUNode)
+			node.Frequency = frequency
+			node.Item.AccessCount >>= shift
+			lfu.insert(node, dest)
+		}
 	}
-	lfu.frequencies[frequency].PushBack(node)
 }
 
-// removeFromFrequency removes a node from its frequency bucket.
+// move places a node at the front of the bucket for frequency.
 //
 // Parameters:
-//   - node: LFU node to remove
-//   - frequency: Frequency level of the bucket
-func (lfu *LFUList) removeFromFrequency(node *LFUNode, frequency int) {
-	if freqList, exists := lfu.frequencies[frequency]; exists {
-		// Find and remove the node from the frequency list
-		for element := freqList.Front(); element != nil; element = element.Next() {
-			if element.Value.(*LFUNode) == node {
-				freqList.Remove(element)
-				break
-			}
-		}
+//   - node: LFU node to move
+//   - frequency: New access frequency of the node
+func (lfu *LFUList) move(node *LFUNode, frequency int) {
+	bucket := node.bucket.Value.(*lfuBucket)
+	if frequency == node.Frequency {
+		bucket.nodes.MoveToFront(node.element)
+		return
 	}
+
+	// Frequencies normally grow, so the search starts from the current bucket
+	var from *list.Element
+	if frequency > node.Frequency {
+		from = node.bucket
+	}
+	dest := lfu.bucketFor(frequency, from)
+
+	lfu.unlink(node)
+	node.Frequency = frequency
+	lfu.insert(node, dest)
 }
 
-// isEmpty checks if a frequency bucket is empty.
+// bucketFor returns the bucket for frequency, creating it if needed.
 //
 // Parameters:
-//   - frequency: Frequency level to check
+//   - frequency: Access frequency of the bucket
+//   - from: Bucket with a frequency not above frequency to start searching from,
+//     nil to start from the lowest frequency
 //
 // Returns:
-//   - bool: true if the bucket is empty or doesn't exist
-func (lfu *LFUList) isEmpty(frequency int) bool {
-	freqList, exists := lfu.frequencies[frequency]
-	return !exists || freqList.Len() == 0
-}
-
-// updateMinFreq recalculates the minimum frequency across all buckets.
-//
-// This method finds the lowest frequency that still contains items,
-// which is needed for efficient eviction operations.
-func (lfu *LFUList) updateMinFreq() {
-	// If current minFreq bucket is empty, find the next non-empty bucket
-	if lfu.isEmpty(lfu.minFreq) {
-		lfu.minFreq = 1 // Reset to minimum possible frequency
-
-		// Find the actual minimum frequency with items
-		for freq, freqList := range lfu.frequencies {
-			if freqList.Len() > 0 {
-				if freq < lfu.minFreq || lfu.minFreq == 1 {
-					lfu.minFreq = freq
-				}
-			}
+//   - *list.Element: Element of the bucket list holding the bucket
+func (lfu *LFUList) bucketFor(frequency int, from *list.Element) *list.Element {
+	at := from
+	if at == nil {
+		at = lfu.buckets.Front()
+		if at == nil || at.Value.(*lfuBucket).frequency > frequency {
+			return lfu.buckets.PushFront(&lfuBucket{frequency: frequency, nodes: list.New()})
 		}
 	}
+
+	for next := at.Next(); next != nil && next.Value.(*lfuBucket).frequency <= frequency; next = at.Next() {
+		at = next
+	}
+	if at.Value.(*lfuBucket).frequency == frequency {
+		return at
+	}
+	return lfu.buckets.InsertAfter(&lfuBucket{frequency: frequency, nodes: list.New()}, at)
+}
+
+// insert adds a node at the front of a bucket.
+//
+// Parameters:
+//   - node: LFU node to add
+//   - bucket: Element of the bucket list holding the destination bucket
+func (lfu *LFUList) insert(node *LFUNode, bucket *list.Element) {
+	node.bucket = bucket
+	node.element = bucket.Value.(*lfuBucket).nodes.PushFront(node)
+}
+
+// unlink removes a node from its bucket, dropping the bucket once it is empty.
+//
+// Parameters:
+//   - node: LFU node to remove
+func (lfu *LFUList) unlink(node *LFUNode) {
+	bucket := node.bucket.Value.(*lfuBucket)
+	bucket.nodes.Remove(node.element)
+	if bucket.nodes.Len() == 0 {
+		lfu.buckets.Remove(node.bucket)
+	}
+	node.bucket, node.element = nil, nil
 }
 
 // FIFONode represents a node in the FIFO (First In First Out) queue.
@@ -527,3 +575,352 @@ func (fifo *FIFOList) Clear() {
 	fifo.list = list.New()
 	fifo.nodeMap = make(map[string]*list.Element)
 }
+
+// SIEVENode represents a node in the SIEVE queue.
+type SIEVENode struct {
+	Key     string     // Cache key for this node
+	Item    *CacheItem // Reference to the actual cache item
+	Visited bool       // Whether the item was accessed since the hand last passed it
+}
+
+// SIEVEList implements the SIEVE eviction policy. Items are kept in insertion
+// order like FIFO, and an access only marks the item as visited. To evict, a
+// hand sweeps from the oldest item towards the newest, clearing visited marks
+// and evicting the first unvisited item; the hand keeps its position between
+// evictions. Popular items therefore stay in place while new items that are
+// never accessed again are evicted quickly.
+//
+// Time Complexity:
+//   - Add: O(1)
+//   - Remove: O(1) with hash map lookup
+//   - Update: O(1) - only sets the visited mark
+//   - RemoveLeast: O(1) amortized
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type SIEVEList struct {
+	list    *list.List               // Queue with the newest item at the front
+	nodeMap map[string]*list.Element // Hash map for O(1) key-to-node lookup
+	hand    *list.Element            // Next eviction candidate (nil = start at the oldest item)
+}
+
+// NewSIEVEList creates a new SIEVE eviction list.
+//
+// Returns:
+//   - *SIEVEList: A new SIEVE list ready for use
+func NewSIEVEList() *SIEVEList {
+	return &SIEVEList{
+		list:    list.New(),
+		nodeMap: make(map[string]*list.Element),
+	}
+}
+
+// Add inserts a new item at the front of the SIEVE queue.
+//
+// Parameters:
+//   - key: Cache key identifier
+//   - item: Cache item to add
+//
+// Adding an existing key only updates the item and marks it as visited.
+func (sieve *SIEVEList) Add(key string, item *CacheItem) {
+	if element, exists := sieve.nodeMap[key]; exists {
+		node := element.Value.(*SIEVENode)
+		node.Item = item
+		node.Visited = true
+		return
+	}
+
+	node := &SIEVENode{
+		Key:  key,
+		Item: item,
+	}
+	sieve.nodeMap[key] = sieve.list.PushFront(node)
+}
+
+// Remove deletes an item from the SIEVE queue.
+//
+// Parameters:
+//   - key: Cache key to remove
+//
+// If the hand points at the removed item, it moves on to the next newer item.
+func (sieve *SIEVEList) Remove(key string) {
+	if element, exists := sieve.nodeMap[key]; exists {
+		if sieve.hand == element {
+			sieve.hand = element.Prev()
+		}
+		sieve.list.Remove(element)
+		delete(sieve.nodeMap, key)
+	}
+}
+
+// Update marks an existing item as visited without changing its queue position.
+//
+// Parameters:
+//   - key: Cache key to update
+//   - item: Updated cache item
+func (sieve *SIEVEList) Update(key string, item *CacheItem) {
+	if element, exists := sieve.nodeMap[key]; exists {
+		node := element.Value.(*SIEVENode)
+		node.Item = item
+		node.Visited = true
+	}
+}
+
+// RemoveLeast evicts the first unvisited item found by the hand.
+//
+// Returns:
+//   - string: Key of the evicted item, empty string if queue is empty
+//
+// The hand clears the visited mark of every item it passes and wraps around
+// to the oldest item when it reaches the front of the queue.
+func (sieve *SIEVEList) RemoveLeast() string {
+	if sieve.list.Len() == 0 {
+		return ""
+	}
+
+	element := sieve.hand
+	if element == nil {
+		element = sieve.list.Back()
+	}
+
+	for node := element.Value.(*SIEVENode); node.Visited; node = element.Value.(*SIEVENode) {
+		node.Visited = false
+		if element = element.Prev(); element == nil {
+			element = sieve.list.Back()
+		}
+	}
+
+	node := element.Value.(*SIEVENode)
+	sieve.hand = element.Prev()
+	sieve.list.Remove(element)
+	delete(sieve.nodeMap, node.Key)
+
+	return node.Key
+}
+
+// Clear removes all items from the SIEVE queue and resets the hand.
+func (sieve *SIEVEList) Clear() {
+	sieve.list = list.New()
+	sieve.nodeMap = make(map[string]*list.Element)
+	sieve.hand = nil
+}
+
+// S3-FIFO parameters
+const (
+	s3fifoSmallPercent = 10 // Share of the total weight kept in the small queue
+	s3fifoMaxFreq      = 3  // Access counters saturate at this value (2-bit counters)
+)
+
+// Queues of the S3-FIFO policy
+const (
+	s3fifoSmall = iota // Small probationary queue receiving new items
+	s3fifoMain         // Main queue holding items that proved useful
+	s3fifoGhost        // Keys recently evicted from the small queue
+)
+
+// S3FIFONode represents an item or a ghost entry tracked by the S3-FIFO policy.
+type S3FIFONode struct {
+	Key       string     // Cache key for this node
+	Item      *CacheItem // Reference to the actual cache item (nil for ghost entries)
+	Weight    int        // Memory weight of the item (at least 1)
+	Frequency int        // Saturating access counter
+	Queue     int        // Queue currently holding the node
+}
+
+// S3FIFOList implements the S3-FIFO eviction policy with three FIFO queues. New
+// items enter a small queue. When they leave it, items accessed more than once
+// move to the main queue and the others are evicted, leaving their key in a ghost
+// queue so that a quick return goes straight to the main queue. The main queue
+// reinserts accessed items (decrementing their counter) instead of evicting them.
+// Since most new items are never accessed again, they are evicted after a short
+// stay in the small queue without disturbing the main queue.
+//
+// Queue sizes are shares of the total weight currently tracked, which approaches
+// the shard limit once the shard is full. The ghost queue holds at most the
+// weight share of the main queue.
+//
+// Time Complexity:
+//   - Add: O(1) amortized
+//   - Remove: O(1) with hash map lookup
+//   - Update: O(1) - only increments a counter
+//   - RemoveLeast: O(1) amortized
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type S3FIFOList struct {
+	queues  [3]*list.List            // Small, main and ghost queues (front = newest)
+	weights [3]int                   // Total weight held by each queue
+	nodeMap map[string]*list.Element // Hash map for O(1) key-to-node lookup, resident and ghost
+}
+
+// NewS3FIFOList creates a new S3-FIFO eviction list.
+//
+// Returns:
+//   - *S3FIFOList: A new S3-FIFO list ready for use
+func NewS3FIFOList() *S3FIFOList {
+	s3 := &S3FIFOList{}
+	s3.Clear()
+	return s3
+}
+
+// Add inserts a new item into the small queue, or into the main queue if its key
+// is found in the ghost queue.
+//
+// Parameters:
+//   - key: Cache key identifier
+//   - item: Cache item to add or update
+//
+// Items carrying accesses over from an item they replace also enter the main
+// queue. Adding a resident key counts as an access.
+func (s3 *S3FIFOList) Add(key string, item *CacheItem) {
+	weight := max(item.Size, 1)
+	dest := s3fifoSmall
+
+	if element, exists := s3.nodeMap[key]; exists {
+		node := element.Value.(*S3FIFONode)
+		if node.Queue != s3fifoGhost {
+			s3.weights[node.Queue] += weight - node.Weight
+			node.Weight = weight
+			s3.Update(key, item)
+			return
+		}
+
+		s3.unlink(element)
+		dest = s3fifoMain
+	} else if item.AccessCount > 0 {
+		dest = s3fifoMain
+	}
+
+	s3.push(&S3FIFONode{Key: key, Item: item, Weight: weight}, dest)
+}
+
+// Remove deletes a resident item from the S3-FIFO queues.
+//
+// Parameters:
+//   - key: Cache key to remove
+//
+// Explicitly removed items leave no ghost entry. Ghost entries are left untouched.
+func (s3 *S3FIFOList) Remove(key string) {
+	if element, exists := s3.nodeMap[key]; exists {
+		if element.Value.(*S3FIFONode).Queue != s3fifoGhost {
+			s3.unlink(element)
+		}
+	}
+}
+
+// Update records an access to a resident item without changing its queue position.
+//
+// Parameters:
+//   - key: Cache key to update
+//   - item: Updated cache item
+func (s3 *S3FIFOList) Update(key string, item *CacheItem) {
+	if element, exists := s3.nodeMap[key]; exists {
+		node := element.Value.(*S3FIFONode)
+		if node.Queue != s3fifoGhost {
+			node.Item = item
+			node.Frequency = min(node.Frequency+1, s3fifoMaxFreq)
+		}
+	}
+}
+
+// RemoveLeast evicts an item from the small queue if it exceeds its share of the
+// total weight, otherwise from the main queue.
+//
+// Returns:
+//   - string: Key of the evicted item, empty string if queues are empty
+//
+// Items leaving the small queue that were accessed more than once move to the
+// main queue instead of being evicted; items at the end of the main queue that
+// were accessed are reinserted with a decremented counter.
+func (s3 *S3FIFOList) RemoveLeast() string {
+	for s3.queues[s3fifoSmall].Len() > 0 || s3.queues[s3fifoMain].Len() > 0 {
+		resident := s3.weights[s3fifoSmall] + s3.weights[s3fifoMain]
+		if s3.queues[s3fifoSmall].Len() > 0 &&
+			(s3.weights[s3fifoSmall]*100 >= resident*s3fifoSmallPercent || s3.queues[s3fifoMain].Len() == 0) {
+			if key, evicted := s3.evictSmall(); evicted {
+				return key
+			}
+			continue
+		}
+
+		if key, evicted := s3.evictMain(); evicted {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// Clear removes all items and ghost entries from the S3-FIFO queues.
+func (s3 *S3FIFOList) Clear() {
+	for i := range s3.queues {
+		s3.queues[i] = list.New()
+		s3.weights[i] = 0
+	}
+	s3.nodeMap = make(map[string]*list.Element)
+}
+
+// evictSmall processes the oldest item of the small queue.
+//
+// Returns:
+//   - string: Key of the evicted item
+//   - bool: true if the item was evicted, false if it moved to the main queue
+func (s3 *S3FIFOList) evictSmall() (string, bool) {
+	element := s3.queues[s3fifoSmall].Back()
+	node := element.Value.(*S3FIFONode)
+	s3.unlink(element)
+
+	if node.Frequency > 1 {
+		node.Frequency = 0
+		s3.push(node, s3fifoMain)
+		return "", false
+	}
+
+	node.Item = nil
+	node.Frequency = 0
+	s3.push(node, s3fifoGhost)
+	ghostMax := (s3.weights[s3fifoSmall] + s3.weights[s3fifoMain]) * (100 - s3fifoSmallPercent) / 100
+	for s3.queues[s3fifoGhost].Len() > 0 && s3.weights[s3fifoGhost] > ghostMax {
+		s3.unlink(s3.queues[s3fifoGhost].Back())
+	}
+	return node.Key, true
+}
+
+// evictMain processes the oldest item of the main queue.
+//
+// Returns:
+//   - string: Key of the evicted item
+//   - bool: true if the item was evicted, false if it was reinserted
+func (s3 *S3FIFOList) evictMain() (string, bool) {
+	element := s3.queues[s3fifoMain].Back()
+	node := element.Value.(*S3FIFONode)
+
+	if node.Frequency > 0 {
+		node.Frequency--
+		s3.queues[s3fifoMain].MoveToFront(element)
+		return "", false
+	}
+
+	s3.unlink(element)
+	return node.Key, true
+}
+
+// push inserts a node at the front of a queue.
+//
+// Parameters:
+//   - node: Node to insert
+//   - dest: Destination queue
+func (s3 *S3FIFOList) push(node *S3FIFONode, dest int) {
+	node.Queue = dest
+	s3.nodeMap[node.Key] = s3.queues[dest].PushFront(node)
+	s3.weights[dest] += node.Weight
+}
+
+// unlink removes a node from its queue and from the key mapping.
+//
+// Parameters:
+//   - element: List element of the node
+func (s3 *S3FIFOList) unlink(element *list.Element) {
+	node := element.Value.(*S3FIFONode)
+	s3.queues[node.Queue].Remove(element)
+	s3.weights[node.Queue] -= node.Weight
+	delete(s3.nodeMap, node.Key)
+}
@@ -1,6 +1,9 @@
 package tscache
 
 import (
+	"fmt"
+	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -191,7 +194,7 @@ func TestFIFOList(t *testing.T) {
 }
 
 func TestEvictionPolicyIntegration(t *testing.T) {
-	policies := []string{"LRU", "LFU", "FIFO"}
+	policies := []string{"LRU", "LFU", "FIFO", "TinyLFU", "ARC", "SIEVE", "S3FIFO", "GDSF", "allkeys-lru", "allkeys-lfu", "volatile-lru", "volatile-ttl"}
 
 	for _, policy := range policies {
 		t.Run(policy+" integration", func(t *testing.T) {
@@ -265,3 +268,466 @@ func TestLFUSpecificFunctionality(t *testing.T) {
 		}
 	})
 }
+
+func TestLFUTieBreaking(t *testing.T) {
+	lfu := NewLFUList()
+
+	items := make(map[string]*CacheItem)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		items[key] = &CacheItem{Key: key}
+		lfu.Add(key, items[key])
+	}
+
+	// 同频率下淘汰最久未使用的数据，访问计数未变化时也刷新使用顺序
+	lfu.Update("a", items["a"])
+	if removed := lfu.RemoveLeast(); removed != "b" {
+		t.Errorf("RemoveLeast() = %q, want b", removed)
+	}
+
+	// 频率跳变时跨越中间的频率桶
+	items["c"].AccessCount = 5
+	lfu.Update("c", items["c"])
+	items["d"].AccessCount = 3
+	lfu.Update("d", items["d"])
+	items["a"].AccessCount = 4
+	lfu.Add("a", items["a"])
+
+	// 频率下降时移入更低的频率桶
+	items["c"].AccessCount = 2
+	lfu.Update("c", items["c"])
+
+	for _, want := range []string{"c", "d", "a", ""} {
+		if removed := lfu.RemoveLeast(); removed != want {
+			t.Errorf("RemoveLeast() = %q, want %q", removed, want)
+		}
+	}
+	if lfu.buckets.Len() != 0 {
+		t.Errorf("%d frequency buckets left after evicting everything", lfu.buckets.Len())
+	}
+}
+
+func TestLFUBuckets(t *testing.T) {
+	lfu := NewLFUList()
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key%d", i)
+		lfu.Add(key, &CacheItem{Key: key, AccessCount: i % 10})
+	}
+
+	// 每个频率只有一个桶，且按频率升序排列
+	if lfu.buckets.Len() != 9 {
+		t.Errorf("bucket count = %d, want 9", lfu.buckets.Len())
+	}
+	prev := 0
+	for element := lfu.buckets.Front(); element != nil; element = element.Next() {
+		bucket := element.Value.(*lfuBucket)
+		if bucket.frequency <= prev {
+			t.Errorf("bucket frequency %d follows %d", bucket.frequency, prev)
+		}
+		prev = bucket.frequency
+	}
+
+	// 删除桶内最后一个数据后桶被回收
+	for i := 0; i < 100; i += 10 {
+		lfu.Remove(fmt.Sprintf("key%d", i+9))
+	}
+	if lfu.buckets.Len() != 8 {
+		t.Errorf("bucket count after removal = %d, want 8", lfu.buckets.Len())
+	}
+
+	for i := 0; i < 90; i++ {
+		if removed := lfu.RemoveLeast(); removed == "" {
+			t.Fatalf("RemoveLeast() returned empty after %d evictions", i)
+		}
+	}
+	if removed := lfu.RemoveLeast(); removed != "" || len(lfu.nodes) != 0 {
+		t.Errorf("RemoveLeast() on empty list = %q with %d nodes", removed, len(lfu.nodes))
+	}
+}
+
+func TestLFUReplace(t *testing.T) {
+	lfu := NewLFUList()
+	for i := 1; i <= 50; i++ {
+		key := fmt.Sprintf("key%d", i)
+		lfu.Add(key, &CacheItem{Key: key, AccessCount: i})
+	}
+
+	// 替换数据项时保留节点和所在的频率桶
+	node := lfu.nodes["key30"]
+	bucket := node.bucket
+	lfu.Replace("key30", &CacheItem{Key: "key30", AccessCount: 30})
+	if lfu.nodes["key30"] != node || node.bucket != bucket || node.Frequency != 30 {
+		t.Errorf("Replace moved key30 to frequency %d", node.Frequency)
+	}
+	if node.Item.AccessCount != 30 || len(lfu.nodes) != 50 {
+		t.Errorf("Replace tracked %d nodes, want 50", len(lfu.nodes))
+	}
+
+	// 未跟踪的数据按新数据添加
+	lfu.Replace("new", &CacheItem{Key: "new"})
+	if node, exists := lfu.nodes["new"]; !exists || node.Frequency != 1 {
+		t.Error("Replace of an untracked key should add it with frequency 1")
+	}
+
+	// 分片覆盖已有数据时原地替换，不重新插入
+	shard := NewCacheShard(1024*1024, EvictionLFU, NewNoCompressor(), 1024*1024)
+	shard.Set("key", toBytes("v1"), 0)
+	for i := 0; i < 5; i++ {
+		shard.Get("key")
+	}
+	list := shard.evictionList.(*LFUList)
+	node = list.nodes["key"]
+	shard.Set("key", toBytes("v2"), 0)
+	if list.nodes["key"] != node || node.Frequency != 5 {
+		t.Errorf("overwrite replaced the LFU node, frequency %d", list.nodes["key"].Frequency)
+	}
+}
+
+// populateLFU 填充size个同频率数据，返回数据项以便后续更新
+func populateLFU(size int) (*LFUList, []*CacheItem) {
+	lfu := NewLFUList()
+	items := make([]*CacheItem, size)
+	for i := range items {
+		items[i] = &CacheItem{Key: strconv.Itoa(i), AccessCount: 1}
+		lfu.Add(items[i].Key, items[i])
+	}
+	return lfu, items
+}
+
+// lfuBenchmarkSizes 覆盖到百万级数据量，验证各操作耗时不随数据量增长
+var lfuBenchmarkSizes = []int{1000, 100000, 1000000, 2000000}
+
+func BenchmarkLFUEvict(b *testing.B) {
+	for _, size := range lfuBenchmarkSizes {
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			lfu, _ := populateLFU(size)
+			keys := make([]string, b.N)
+			for i := range keys {
+				keys[i] = "new" + strconv.Itoa(i)
+			}
+
+			// 所有数据同频率，模拟淘汰时最坏的情况
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				lfu.Add(keys[i], &CacheItem{Key: keys[i], AccessCount: 1})
+				lfu.RemoveLeast()
+			}
+		})
+	}
+}
+
+func BenchmarkLFUUpdate(b *testing.B) {
+	for _, size := range lfuBenchmarkSizes {
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			lfu, items := populateLFU(size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				item := items[i%size]
+				item.AccessCount++
+				lfu.Update(item.Key, item)
+			}
+		})
+	}
+}
+
+func BenchmarkLFURemove(b *testing.B) {
+	for _, size := range lfuBenchmarkSizes {
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			lfu, items := populateLFU(size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				item := items[i%size]
+				lfu.Remove(item.Key)
+				lfu.Add(item.Key, item)
+			}
+		})
+	}
+}
+
+func BenchmarkLFUReplace(b *testing.B) {
+	// 每个数据频率不同，对比原地替换与先删除再添加
+	for _, size := range []int{100, 1000, 10000} {
+		items := make([]*CacheItem, size)
+		for i := range items {
+			items[i] = &CacheItem{Key: strconv.Itoa(i), AccessCount: i + 1}
+		}
+		populate := func() *LFUList {
+			lfu := NewLFUList()
+			for _, item := range items {
+				lfu.Add(item.Key, item)
+			}
+			return lfu
+		}
+
+		b.Run(fmt.Sprintf("Replace/frequencies=%d", size), func(b *testing.B) {
+			lfu := populate()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				item := items[size-1-i%size]
+				lfu.Replace(item.Key, item)
+			}
+		})
+		b.Run(fmt.Sprintf("RemoveAdd/frequencies=%d", size), func(b *testing.B) {
+			lfu := populate()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				item := items[size-1-i%size]
+				lfu.Remove(item.Key)
+				lfu.Add(item.Key, item)
+			}
+		})
+	}
+}
+
+// evictionSimulation 记录访问序列回放的结果
+type evictionSimulation struct {
+	resident map[string]bool // 回放结束时驻留的key
+	hits     int             // 命中次数
+	requests int             // 访问次数
+}
+
+// hitRatio 返回命中率
+func (sim evictionSimulation) hitRatio() float64 {
+	return float64(sim.hits) / float64(sim.requests)
+}
+
+// simulateEviction 以固定容量（按数据项计）回放访问序列
+func simulateEviction(list EvictionList, capacity int, keys []string) evictionSimulation {
+	sim := evictionSimulation{resident: make(map[string]bool)}
+	for _, key := range keys {
+		sim.requests++
+		item := &CacheItem{Key: key, Size: 1}
+		if sim.resident[key] {
+			sim.hits++
+			list.Update(key, item)
+			continue
+		}
+
+		list.Add(key, item)
+		sim.resident[key] = true
+		for len(sim.resident) > capacity {
+			delete(sim.resident, list.RemoveLeast())
+		}
+	}
+	return sim
+}
+
+// scanWorkload 生成热点数据与一次性扫描交替访问的序列
+func scanWorkload(hot, scan int) []string {
+	var keys []string
+	for round := 0; round < 5; round++ {
+		for i := 0; i < hot; i++ {
+			keys = append(keys, fmt.Sprintf("hot%d", i))
+		}
+	}
+
+	// 扫描期间热点数据仍被访问，但重用距离超过缓存容量
+	for i := 0; i < scan; i++ {
+		keys = append(keys, fmt.Sprintf("scan%d", i))
+		if i%2 == 0 {
+			keys = append(keys, fmt.Sprintf("hot%d", (i/2)%hot))
+		}
+	}
+	return keys
+}
+
+// zipfWorkload 生成服从Zipf分布的访问序列，模拟Web访问热度
+func zipfWorkload(n, keySpace int, skew float64) []string {
+	rng := rand.New(rand.NewSource(42))
+	zipf := rand.NewZipf(rng, skew, 1, uint64(keySpace-1))
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", zipf.Uint64())
+	}
+	return keys
+}
+
+// evictionPolicies 列出参与对比的淘汰策略
+var evictionPolicies = []struct {
+	name    string
+	newList func(capacity int) EvictionList
+}{
+	{"LRU", func(int) EvictionList { return NewLRUList() }},
+	{"SIEVE", func(int) EvictionList { return NewSIEVEList() }},
+	{"S3FIFO", func(int) EvictionList { return NewS3FIFOList() }},
+	{"TinyLFU", func(capacity int) EvictionList { return newTestTinyLFUList(capacity) }},
+	{"ARC", func(capacity int) EvictionList { return NewARCList(capacity) }},
+	{"GDSF", func(int) EvictionList { return NewGDSFList() }},
+}
+
+func TestSIEVEList(t *testing.T) {
+	sieve := NewSIEVEList()
+
+	for i := 0; i < 4; i++ {
+		key := fmt.Sprintf("key%d", i)
+		sieve.Add(key, &CacheItem{Key: key})
+	}
+
+	// 被访问的数据保留，指针跳过并清除访问标记
+	sieve.Update("key0", &CacheItem{Key: "key0"})
+	sieve.Update("key1", &CacheItem{Key: "key1"})
+	if removed := sieve.RemoveLeast(); removed != "key2" {
+		t.Errorf("RemoveLeast() = %q, want key2", removed)
+	}
+
+	// 指针位置在两次淘汰之间保持不变
+	sieve.Add("key4", &CacheItem{Key: "key4"})
+	if removed := sieve.RemoveLeast(); removed != "key3" {
+		t.Errorf("RemoveLeast() = %q, want key3", removed)
+	}
+
+	// 删除指针所指的数据不应出错
+	sieve.Remove("key4")
+	sieve.Remove("nonexistent")
+	sieve.Update("nonexistent", &CacheItem{})
+
+	// 所有访问标记已被清除，按插入顺序淘汰
+	for _, want := range []string{"key0", "key1", ""} {
+		if removed := sieve.RemoveLeast(); removed != want {
+			t.Errorf("RemoveLeast() = %q, want %q", removed, want)
+		}
+	}
+
+	sieve.Add("key5", &CacheItem{Key: "key5"})
+	sieve.Clear()
+	if removed := sieve.RemoveLeast(); removed != "" {
+		t.Errorf("RemoveLeast() after Clear = %q, want empty", removed)
+	}
+}
+
+func TestS3FIFOList(t *testing.T) {
+	s3 := NewS3FIFOList()
+
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("key%d", i)
+		s3.Add(key, &CacheItem{Key: key, Size: 1})
+	}
+
+	// 小队列中访问超过一次的数据进入主队列
+	s3.Update("key0", &CacheItem{Key: "key0", Size: 1})
+	s3.Update("key0", &CacheItem{Key: "key0", Size: 1})
+	if removed := s3.RemoveLeast(); removed != "key1" {
+		t.Errorf("RemoveLeast() = %q, want key1", removed)
+	}
+	if node := s3.nodeMap["key0"].Value.(*S3FIFONode); node.Queue != s3fifoMain {
+		t.Errorf("key0 should be in the main queue, got %d", node.Queue)
+	}
+
+	// 被淘汰的key进入ghost队列，再次加入时直接进入主队列
+	if node := s3.nodeMap["key1"].Value.(*S3FIFONode); node.Queue != s3fifoGhost || node.Item != nil {
+		t.Errorf("key1 should be a ghost, got queue %d", node.Queue)
+	}
+	s3.Add("key1", &CacheItem{Key: "key1", Size: 1})
+	if node := s3.nodeMap["key1"].Value.(*S3FIFONode); node.Queue != s3fifoMain {
+		t.Errorf("key1 should return to the main queue, got %d", node.Queue)
+	}
+
+	s3.Remove("key2")
+	s3.Remove("nonexistent") // 不应该出错
+	if _, exists := s3.nodeMap["key2"]; exists {
+		t.Error("explicitly removed keys should not leave a ghost")
+	}
+
+	seen := make(map[string]bool)
+	for key := s3.RemoveLeast(); key != ""; key = s3.RemoveLeast() {
+		if seen[key] {
+			t.Errorf("key %s evicted twice", key)
+		}
+		seen[key] = true
+	}
+	if len(seen) != 9 {
+		t.Errorf("evicted %d keys, want 9", len(seen))
+	}
+	if resident := s3.weights[s3fifoSmall] + s3.weights[s3fifoMain]; resident != 0 {
+		t.Errorf("resident weight = %d after evicting everything, want 0", resident)
+	}
+}
+
+func TestEvictionHitRatio(t *testing.T) {
+	keys := zipfWorkload(200000, 100000, 1.01)
+	const capacity = 1000
+
+	lru := simulateEviction(NewLRUList(), capacity, keys).hitRatio()
+	for _, policy := range evictionPolicies {
+		ratio := simulateEviction(policy.newList(capacity), capacity, keys).hitRatio()
+		t.Logf("%s hit ratio: %.4f", policy.name, ratio)
+
+		// 在Zipf分布下各策略不应明显差于LRU
+		if ratio < lru*0.98 {
+			t.Errorf("%s hit ratio %.4f is worse than LRU %.4f", policy.name, ratio, lru)
+		}
+	}
+}
+
+func BenchmarkEvictionHitRatio(b *testing.B) {
+	keys := zipfWorkload(1000000, 1000000, 1.01)
+
+	for _, capacity := range []int{1000, 10000} {
+		for _, policy := range evictionPolicies {
+			b.Run(fmt.Sprintf("%s/capacity=%d", policy.name, capacity), func(b *testing.B) {
+				var sim evictionSimulation
+				for i := 0; i < b.N; i++ {
+					sim = simulateEviction(policy.newList(capacity), capacity, keys)
+				}
+				b.ReportMetric(sim.hitRatio()*100, "hit%")
+			})
+		}
+	}
+}
+
+func BenchmarkEvictionThroughput(b *testing.B) {
+	const capacity = 10000
+	keys := zipfWorkload(1<<20, 1000000, 1.01)
+
+	for _, policy := range evictionPolicies {
+		b.Run(policy.name, func(b *testing.B) {
+			list := policy.newList(capacity)
+			items := make(map[string]*CacheItem, capacity)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := keys[i&(len(keys)-1)]
+				if item, exists := items[key]; exists {
+					list.Update(key, item)
+					continue
+				}
+
+				item := &CacheItem{Key: key, Size: 1}
+				list.Add(key, item)
+				items[key] = item
+				for len(items) > capacity {
+					delete(items, list.RemoveLeast())
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEvictionCache(b *testing.B) {
+	keys := zipfWorkload(1<<16, 100000, 1.01)
+	value := make([]byte, 100)
+
+	for _, policy := range []string{EvictionLRU, EvictionSIEVE, EvictionS3FIFO} {
+		b.Run(policy, func(b *testing.B) {
+			cache := NewCache(WithMaxSize(1024*1024), WithEvictionPolicy(policy))
+
+			b.RunParallel(func(pb *testing.PB) {
+				i := rand.Int()
+				for pb.Next() {
+					key := keys[i&(len(keys)-1)]
+					if _, err := cache.Get(key); err != nil {
+						cache.Set(key, value, 0)
+					}
+					i++
+				}
+			})
+
+			stats := cache.Stats()
+			b.ReportMetric(float64(stats.Hits)*100/float64(stats.Hits+stats.Misses), "hit%")
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package tscache
+
+import (
+	"container/heap"
+)
+
+// GDSFNode represents an item tracked by the GDSF policy.
+type GDSFNode struct {
+	Key      string     // Cache key for this node
+	Item     *CacheItem // Reference to the actual cache item
+	Priority float64    // Eviction priority, lowest is evicted first
+	seq      uint64     // Order of the last access, breaks priority ties in LRU order
+	index    int        // Position of the node in the heap
+}
+
+// gdsfHeap is a min-heap of nodes ordered by priority.
+type gdsfHeap []*GDSFNode
+
+func (h gdsfHeap) Len() int { return len(h) }
+
+func (h gdsfHeap) Less(i, j int) bool {
+	if h[i].Priority != h[j].Priority {
+		return h[i].Priority < h[j].Priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h gdsfHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *gdsfHeap) Push(x any) {
+	node := x.(*GDSFNode)
+	node.index = len(*h)
+	*h = append(*h, node)
+}
+
+func (h *gdsfHeap) Pop() any {
+	old := *h
+	n := len(old)
+	node := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return node
+}
+
+// GDSFList implements the Greedy-Dual-Size-Frequency eviction policy. Every item
+// has the priority
+//
+//	L + frequency × cost / size
+//
+// and the item with the lowest priority is evicted. Frequency is the item's access
+// count, cost the recompute cost passed with SetOptions.Cost (1 if unset) and size
+// the item's memory size, so large, rarely used and cheap items go first. L is an
+// inflation value raised to the priority of every evicted item; since priorities
+// are only recomputed on access, items that stop being accessed fall behind newly
+// added ones over time.
+//
+// Time Complexity:
+//   - Add: O(log n)
+//   - Remove: O(log n) with hash map lookup
+//   - Update: O(log n)
+//   - RemoveLeast: O(log n)
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type GDSFList struct {
+	heap      gdsfHeap             // Min-heap of nodes ordered by priority
+	nodeMap   map[string]*GDSFNode // Hash map for O(1) key-to-node lookup
+	inflation float64              // Priority of the last evicted item (L)
+	seq       uint64               // Access counter for tie-breaking
+}
+
+// NewGDSFList creates a new GDSF eviction list.
+//
+// Returns:
+//   - *GDSFList: A new GDSF list ready for use
+func NewGDSFList() *GDSFList {
+	gdsf := &GDSFList{}
+	gdsf.Clear()
+	return gdsf
+}
+
+// Add inserts a new item or refreshes the priority of an existing item.
+//
+// Parameters:
+//   - key: Cache key identifier
+//   - item: Cache item to add or update
+func (gdsf *GDSFList) Add(key string, item *CacheItem) {
+	if _, exists := gdsf.nodeMap[key]; exists {
+		gdsf.Update(key, item)
+		return
+	}
+
+	gdsf.seq++
+	node := &GDSFNode{Key: key, Item: item, Priority: gdsf.priority(item), seq: gdsf.seq}
+	gdsf.nodeMap[key] = node
+	heap.Push(&gdsf.heap, node)
+}
+
+// Remove deletes an item from the GDSF list.
+//
+// Parameters:
+//   - key: Cache key to remove
+func (gdsf *GDSFList) Remove(key string) {
+	if node, exists := gdsf.nodeMap[key]; exists {
+		heap.Remove(&gdsf.heap, node.index)
+		delete(gdsf.nodeMap, key)
+	}
+}
+
+// Update recomputes an item's priority after an access.
+//
+// Parameters:
+//   - key: Cache key to update
+//   - item: Updated cache item
+func (gdsf *GDSFList) Update(key string, item *CacheItem) {
+	node, exists := gdsf.nodeMap[key]
+	if !exists {
+		return
+	}
+
+	gdsf.seq++
+	node.Item = item
+	node.Priority = gdsf.priority(item)
+	node.seq = gdsf.seq
+	heap.Fix(&gdsf.heap, node.index)
+}
+
+// RemoveLeast evicts the item with the lowest priority.
+//
+// Returns:
+//   - string: Key of the evicted item, empty string if list is empty
+//
+// The inflation value is raised to the priority of the evicted item.
+func (gdsf *GDSFList) RemoveLeast() string {
+	if gdsf.heap.Len() == 0 {
+		return ""
+	}
+
+	node := heap.Pop(&gdsf.heap).(*GDSFNode)
+	delete(gdsf.nodeMap, node.Key)
+	gdsf.inflation = node.Priority
+
+	return node.Key
+}
+
+// Clear removes all items from the GDSF list and resets the inflation value.
+func (gdsf *GDSFList) Clear() {
+	gdsf.heap = nil
+	gdsf.nodeMap = make(map[string]*GDSFNode)
+	gdsf.inflation = 0
+	gdsf.seq = 0
+}
+
+// priority computes the current priority of an item.
+//
+// Parameters:
+//   - item: Cache item to rate
+//
+// Returns:
+//   - float64: Inflation plus frequency times cost per byte
+func (gdsf *GDSFList) priority(item *CacheItem) float64 {
+	cost := item.Cost
+	if cost <= 0 {
+		cost = 1
+	}
+	frequency := float64(max(item.AccessCount, 1))
+
+	return gdsf.inflation + frequency*cost/float64(max(item.Size, 1))
+}
@@ -0,0 +1,175 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGDSFList(t *testing.T) {
+	gdsf := NewGDSFList()
+
+	items := map[string]*CacheItem{
+		"big":   {Key: "big", Size: 5 * 1024 * 1024, AccessCount: 10},
+		"small": {Key: "small", Size: 100, AccessCount: 1},
+		"warm":  {Key: "warm", Size: 100, AccessCount: 5},
+		"cheap": {Key: "cheap", Size: 100, AccessCount: 1, Cost: 0.5},
+		"dear":  {Key: "dear", Size: 100, AccessCount: 1, Cost: 100},
+	}
+	for _, key := range []string{"big", "small", "warm", "cheap", "dear"} {
+		gdsf.Add(key, items[key])
+	}
+
+	// 大对象即使访问较多，单位字节价值也最低；其次按频率与代价排序
+	for _, want := range []string{"big", "cheap", "small", "warm", "dear"} {
+		if removed := gdsf.RemoveLeast(); removed != want {
+			t.Errorf("RemoveLeast() = %q, want %q", removed, want)
+		}
+	}
+	if removed := gdsf.RemoveLeast(); removed != "" {
+		t.Errorf("RemoveLeast() on empty list = %q", removed)
+	}
+
+	// 删除与清空
+	gdsf.Add("key1", &CacheItem{Key: "key1", Size: 10})
+	gdsf.Add("key2", &CacheItem{Key: "key2", Size: 10})
+	gdsf.Remove("key1")
+	gdsf.Remove("nonexistent") // 不应该出错
+	gdsf.Update("nonexistent", &CacheItem{})
+	if removed := gdsf.RemoveLeast(); removed != "key2" {
+		t.Errorf("RemoveLeast() = %q, want key2", removed)
+	}
+
+	gdsf.Add("key3", &CacheItem{Key: "key3", Size: 10})
+	gdsf.Clear()
+	if removed := gdsf.RemoveLeast(); removed != "" || gdsf.inflation != 0 {
+		t.Errorf("after Clear: RemoveLeast() = %q, inflation = %v", removed, gdsf.inflation)
+	}
+}
+
+func TestGDSFFrequency(t *testing.T) {
+	gdsf := NewGDSFList()
+
+	a := &CacheItem{Key: "a", Size: 100}
+	b := &CacheItem{Key: "b", Size: 100}
+	gdsf.Add("a", a)
+	gdsf.Add("b", b)
+
+	// 访问提升优先级
+	a.AccessCount = 3
+	gdsf.Update("a", a)
+	if removed := gdsf.RemoveLeast(); removed != "b" {
+		t.Errorf("RemoveLeast() = %q, want b", removed)
+	}
+
+	// 优先级相同时淘汰最久未访问的数据
+	c := &CacheItem{Key: "c", Size: 100, AccessCount: 3}
+	gdsf.Add("c", c)
+	gdsf.Update("a", a)
+	if removed := gdsf.RemoveLeast(); removed != "c" {
+		t.Errorf("RemoveLeast() = %q, want c", removed)
+	}
+}
+
+func TestGDSFInflation(t *testing.T) {
+	gdsf := NewGDSFList()
+
+	// 旧热点数据之后不再被访问
+	gdsf.Add("old", &CacheItem{Key: "old", Size: 1, AccessCount: 50})
+
+	// 新数据不断加入并被淘汰，抬高基准值，旧热点最终被淘汰
+	evictedOld := false
+	for i := 0; i < 100 && !evictedOld; i++ {
+		key := fmt.Sprintf("key%d", i)
+		gdsf.Add(key, &CacheItem{Key: key, Size: 1, AccessCount: 1})
+		evictedOld = gdsf.RemoveLeast() == "old"
+	}
+	if !evictedOld {
+		t.Error("inflation should eventually let newer items outrank an item that is no longer accessed")
+	}
+}
+
+func TestGDSFHitRatio(t *testing.T) {
+	// 少量大对象只被访问一次，大量小对象被反复访问
+	var keys []string
+	sizes := make(map[string]int)
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("small%d", i)
+			sizes[key] = 100
+			keys = append(keys, key)
+		}
+		for i := 0; i < 3; i++ {
+			key := fmt.Sprintf("big%d-%d", round, i)
+			sizes[key] = 5000
+			keys = append(keys, key)
+		}
+	}
+
+	const capacity = 15000
+	replay := func(list EvictionList) int {
+		items := make(map[string]*CacheItem)
+		used, hits := 0, 0
+		for _, key := range keys {
+			if item, exists := items[key]; exists {
+				hits++
+				item.AccessCount++
+				list.Update(key, item)
+				continue
+			}
+
+			item := &CacheItem{Key: key, Size: sizes[key]}
+			items[key] = item
+			used += item.Size
+			list.Add(key, item)
+			for used > capacity {
+				evicted := list.RemoveLeast()
+				used -= items[evicted].Size
+				delete(items, evicted)
+			}
+		}
+		return hits
+	}
+
+	lru := replay(NewLRUList())
+	gdsf := replay(NewGDSFList())
+	t.Logf("hits: LRU %d, GDSF %d", lru, gdsf)
+	if gdsf <= lru {
+		t.Errorf("GDSF hits %d should exceed LRU hits %d", gdsf, lru)
+	}
+}
+
+func TestGDSFCacheIntegration(t *testing.T) {
+	cache, err := NewCacheE(WithMaxSize(64*1024), WithEvictionPolicy(EvictionGDSF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy := cache.Stats().EvictionPolicy; policy != EvictionGDSF {
+		t.Errorf("EvictionPolicy = %q, want GDSF", policy)
+	}
+
+	shard := cache.getShard("expensive")
+	if err := cache.SetWithOptions("expensive", make([]byte, 100), SetOptions{Cost: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if item := shard.data["expensive"]; item.Cost != 1000 {
+		t.Errorf("stored Cost = %v, want 1000", item.Cost)
+	}
+
+	// 填满缓存，代价高的数据应被保留
+	for i := 0; i < 2000; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), make([]byte, 100), 0)
+	}
+	if _, err := cache.Get("expensive"); err != nil {
+		t.Errorf("expensive item was evicted: %v", err)
+	}
+	if stats := cache.Stats(); stats.Evictions == 0 || stats.CurrentSize > stats.MaxSize {
+		t.Errorf("Evictions = %d, CurrentSize = %d, MaxSize = %d", stats.Evictions, stats.CurrentSize, stats.MaxSize)
+	}
+
+	// 计数器更新保留代价
+	cache.SetWithOptions("counter", toBytes("1"), SetOptions{Cost: 50})
+	cache.Incr("counter", 0)
+	if item := cache.getShard("counter").data["counter"]; item.Cost != 50 {
+		t.Errorf("Cost after Incr = %v, want 50", item.Cost)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/tinystack/tscache
 
-go 1.22
+go 1.23
 
 require github.com/klauspost/compress v1.18.0
@@ -0,0 +1,88 @@
+package tscache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCacheIdleTimeout(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.SetWithOptions("session", toBytes("data"), SetOptions{IdleTimeout: 40 * time.Millisecond})
+
+	// 每次成功读取都会续期
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		if _, err := cache.Get("session"); err != nil {
+			t.Fatalf("Get %d failed: %v", i, err)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := cache.Get("session"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get after idle period error = %v, want ErrKeyNotFound", err)
+	}
+	if stats := cache.Stats(); stats.Expirations != 1 {
+		t.Errorf("Expirations = %d, want 1", stats.Expirations)
+	}
+}
+
+func TestCacheIdleTimeoutWithTTL(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.SetWithOptions("session", toBytes("data"), SetOptions{
+		TTL:         80 * time.Millisecond,
+		IdleTimeout: 40 * time.Millisecond,
+	})
+
+	// 绝对过期时间不会因访问而延长
+	deadline := time.Now().Add(120 * time.Millisecond)
+	expired := false
+	for time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+		if _, err := cache.Get("session"); err != nil {
+			expired = true
+			break
+		}
+	}
+	if !expired {
+		t.Error("item should expire at its absolute TTL despite being accessed")
+	}
+}
+
+func TestCacheIdleTimeoutTTL(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.SetWithOptions("idle", toBytes("data"), SetOptions{TTL: time.Hour, IdleTimeout: time.Minute})
+	if ttl, err := cache.TTL("idle"); err != nil || ttl <= 59*time.Second || ttl > time.Minute {
+		t.Errorf("TTL = %v, %v; want about 1m", ttl, err)
+	}
+
+	cache.SetWithOptions("absolute", toBytes("data"), SetOptions{TTL: time.Minute, IdleTimeout: time.Hour})
+	if ttl, err := cache.TTL("absolute"); err != nil || ttl <= 59*time.Second || ttl > time.Minute {
+		t.Errorf("TTL = %v, %v; want about 1m", ttl, err)
+	}
+}
+
+func TestCacheIdleTimeoutJanitor(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithCleanupInterval(10*time.Millisecond))
+	defer cache.Close()
+
+	cache.SetWithOptions("active", toBytes("data"), SetOptions{IdleTimeout: 50 * time.Millisecond})
+	cache.SetWithOptions("inactive", toBytes("data"), SetOptions{IdleTimeout: 50 * time.Millisecond})
+
+	// 后台清理只删除空闲的数据
+	for i := 0; i < 8; i++ {
+		time.Sleep(20 * time.Millisecond)
+		cache.Get("active")
+	}
+
+	stats := cache.Stats()
+	if stats.CurrentCount != 1 {
+		t.Errorf("CurrentCount = %d, want 1", stats.CurrentCount)
+	}
+	if _, err := cache.TTL("active"); err != nil {
+		t.Errorf("active session should remain: %v", err)
+	}
+}
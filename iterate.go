@@ -0,0 +1,102 @@
+package tscache
+
+import (
+	"iter"
+	"time"
+)
+
+// ItemInfo exposes the metadata of a cached item during iteration.
+type ItemInfo struct {
+	Size        int           // Memory size of the item in bytes
+	ExpireAt    time.Time     // Expiration timestamp (zero value = no expiration)
+	IdleTimeout time.Duration // Inactivity period after which the item expires (0 = none)
+	CreatedAt   time.Time     // Creation timestamp
+	AccessAt    time.Time     // Last access timestamp
+	AccessCount int           // Number of recorded accesses
+	Compressed  bool          // Whether the value is stored compressed
+	Version     uint64        // Write version of the item
+}
+
+// rangeEntry is a snapshot of a single item taken while holding the shard lock.
+type rangeEntry struct {
+	item *CacheItem // The stored item (its value is immutable)
+	info ItemInfo   // Metadata captured at snapshot time
+}
+
+// Range calls fn for every live item in the cache until fn returns false.
+//
+// Parameters:
+//   - fn: Callback receiving the key, the decompressed value and the item metadata
+//
+// Shards are visited one at a time: each shard is snapshotted under its read lock,
+// and fn is called after the lock has been released, so fn may call back into the
+// cache. The iteration therefore reflects each shard at the moment it was visited,
+// not the cache as a whole at a single point in time. Expired items and items whose
+// value cannot be decompressed are skipped. Iteration does not count as access and
+// updates neither statistics nor eviction order.
+func (c *Cache) Range(fn func(key string, value []byte, meta ItemInfo) bool) {
+	for _, shard := range c.shards {
+		for _, entry := range shard.snapshot(time.Now()) {
+			value, err := shard.decode(entry.item)
+			if err != nil {
+				continue
+			}
+			if !fn(entry.item.Key, value, entry.info) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys and values of every live item in the cache.
+//
+// Returns:
+//   - iter.Seq2[string, []byte]: Iterator yielding key-value pairs
+//
+// It follows the same consistency rules as Range:
+//
+//	for key, value := range cache.All() {
+//		fmt.Println(key, string(value))
+//	}
+func (c *Cache) All() iter.Seq2[string, []byte] {
+	return func(yield func(string, []byte) bool) {
+		c.Range(func(key string, value []byte, _ ItemInfo) bool {
+			return yield(key, value)
+		})
+	}
+}
+
+// snapshot captures the live items of this shard.
+//
+// Parameters:
+//   - now: Reference time for the expiration check
+//
+// Returns:
+//   - []rangeEntry: Items and their metadata at the time of the call
+func (s *CacheShard) snapshot(now time.Time) []rangeEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]rangeEntry, 0, len(s.data))
+	for _, item := range s.data {
+		if item.isExpired(now) || item.negative {
+			continue
+		}
+
+		entries = append(entries, rangeEntry{
+			item: item,
+			info: ItemInfo{
+				Size:        item.Size,
+				ExpireAt:    item.ExpireAt,
+				IdleTimeout: item.IdleTimeout,
+				CreatedAt:   item.CreatedAt,
+				AccessAt:    item.AccessAt,
+				AccessCount: item.AccessCount,
+				Compressed:  item.Compressed,
+				Version:     item.Version,
+			},
+		})
+	}
+
+	return entries
+}
@@ -0,0 +1,88 @@
+package tscache
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheRange(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithCompressor(NewGzipCompressor()), WithCompressSize(64))
+
+	for i := 0; i < 20; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), toBytes(fmt.Sprintf("value%d", i)), 0)
+	}
+	large := strings.Repeat("compressible ", 50)
+	cache.Set("large", toBytes(large), time.Hour)
+	cache.Set("expired", toBytes("value"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	seen := make(map[string]ItemInfo)
+	cache.Range(func(key string, value []byte, meta ItemInfo) bool {
+		if key == "large" && string(value) != large {
+			t.Error("Range should pass decompressed values")
+		}
+		seen[key] = meta
+		return true
+	})
+
+	// 过期数据应被跳过
+	if len(seen) != 21 {
+		t.Errorf("Range visited %d items, want 21", len(seen))
+	}
+	if _, ok := seen["expired"]; ok {
+		t.Error("Range should skip expired items")
+	}
+
+	// 元数据
+	meta := seen["large"]
+	if !meta.Compressed || meta.ExpireAt.IsZero() || meta.CreatedAt.IsZero() {
+		t.Errorf("unexpected metadata for large: %+v", meta)
+	}
+
+	// 遍历不影响命中统计
+	if stats := cache.Stats(); stats.Hits != 0 {
+		t.Errorf("Hits = %d after Range, want 0", stats.Hits)
+	}
+}
+
+func TestCacheRangeStop(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+	for i := 0; i < 20; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), toBytes("value"), 0)
+	}
+
+	visited := 0
+	cache.Range(func(key string, value []byte, meta ItemInfo) bool {
+		visited++
+		return visited < 5
+	})
+	if visited != 5 {
+		t.Errorf("visited %d items, want 5", visited)
+	}
+}
+
+func TestCacheAll(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+	for i := 0; i < 10; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), toBytes(fmt.Sprintf("value%d", i)), 0)
+	}
+
+	count := 0
+	for key, value := range cache.All() {
+		// 遍历过程中可以安全地修改缓存
+		cache.Delete(key)
+		if strings.TrimPrefix(key, "key") != strings.TrimPrefix(string(value), "value") {
+			t.Errorf("mismatched pair %s=%s", key, string(value))
+		}
+		count++
+	}
+
+	if count != 10 {
+		t.Errorf("All yielded %d items, want 10", count)
+	}
+	if stats := cache.Stats(); stats.CurrentCount != 0 {
+		t.Errorf("CurrentCount = %d, want 0", stats.CurrentCount)
+	}
+}
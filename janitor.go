@@ -0,0 +1,101 @@
+package tscache
+
+import (
+	"time"
+)
+
+// startJanitor launches the background sweeper that periodically removes expired items.
+//
+// Parameters:
+//   - interval: Time between two consecutive sweeps
+//
+// The expiration index is rebuilt with a tick no coarser than the interval, so this
+// must be called before the shard holds any items. The sweeper runs until stopJanitor is called.
+func (s *CacheShard) startJanitor(interval time.Duration) {
+	s.expiry = newTimingWheel(wheelTickFor(interval), time.Now())
+	s.janitorStop = make(chan struct{})
+	s.janitorDone = make(chan struct{})
+
+	go func() {
+		defer close(s.janitorDone)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.removeExpired()
+			case <-s.janitorStop:
+				return
+			}
+		}
+	}()
+}
+
+// stopJanitor stops the background sweeper and waits for it to exit.
+//
+// This operation is a no-op if the sweeper was never started.
+func (s *CacheShard) stopJanitor() {
+	if s.janitorStop == nil {
+		return
+	}
+
+	close(s.janitorStop)
+	<-s.janitorDone
+}
+
+// removeExpired deletes every expired item from the shard.
+//
+// Returns:
+//   - int: Number of items removed
+//
+// Expired items are found through the shard's timing wheel, so the work done is
+// proportional to the number of items that expired rather than the shard size.
+// Removed items are counted as expirations, not evictions.
+func (s *CacheShard) removeExpired() int {
+	now := time.Now()
+
+	s.mu.Lock()
+	removed := s.removeExpiredLocked(now)
+	s.unlock()
+
+	if removed > 0 {
+		s.stats.mu.Lock()
+		s.stats.Expirations += removed
+		s.stats.mu.Unlock()
+	}
+
+	return removed
+}
+
+// removeExpiredLocked advances the expiration index to now and deletes the items it reports.
+//
+// Parameters:
+//   - now: Current time
+//
+// Returns:
+//   - int: Number of items removed
+//
+// The caller must hold the shard lock and is responsible for updating statistics.
+func (s *CacheShard) removeExpiredLocked(now time.Time) int {
+	removed := 0
+	s.expiry.advance(now, func(key string) {
+		item, exists := s.data[key]
+		if !exists {
+			return
+		}
+
+		// The tick boundary may coincide with the exact expiration time, and idle
+		// items renewed by access expire later than they were scheduled
+		if !item.isExpired(now.Add(-s.staleTTL)) {
+			s.scheduleExpiry(item)
+			return
+		}
+
+		s.deleteLocked(key, item, Expired)
+		removed++
+	})
+
+	return removed
+}
@@ -0,0 +1,68 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCacheCleanupInterval(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithCleanupInterval(10*time.Millisecond))
+	defer cache.Close()
+
+	// 写入一批短TTL的数据，之后不再读取
+	for i := 0; i < 50; i++ {
+		cache.Set(fmt.Sprintf("short_%d", i), toBytes("value"), 20*time.Millisecond)
+	}
+	cache.Set("forever", toBytes("value"), 0)
+
+	// 等待后台清理
+	time.Sleep(100 * time.Millisecond)
+
+	stats := cache.Stats()
+	if stats.CurrentCount != 1 {
+		t.Errorf("CurrentCount = %d, want 1", stats.CurrentCount)
+	}
+	if stats.CurrentSize != len("value") {
+		t.Errorf("CurrentSize = %d, want %d", stats.CurrentSize, len("value"))
+	}
+	if stats.Expirations != 50 {
+		t.Errorf("Expirations = %d, want 50", stats.Expirations)
+	}
+	if stats.Evictions != 0 {
+		t.Errorf("Evictions = %d, want 0", stats.Evictions)
+	}
+}
+
+func TestCacheLazyExpirationStats(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.Set("key", toBytes("value"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	// 访问过期数据时同步删除并计入过期统计
+	if _, err := cache.Get("key"); err == nil {
+		t.Error("Expected error for expired key")
+	}
+
+	stats := cache.Stats()
+	if stats.Expirations != 1 || stats.CurrentCount != 0 {
+		t.Errorf("Expirations = %d, CurrentCount = %d, want 1 and 0", stats.Expirations, stats.CurrentCount)
+	}
+}
+
+func TestCacheClose(t *testing.T) {
+	cache := NewCache(WithCleanupInterval(time.Millisecond))
+
+	// 多次关闭应当是安全的
+	cache.Close()
+	cache.Close()
+
+	// 关闭后缓存仍然可用
+	if err := cache.Set("key", toBytes("value"), 0); err != nil {
+		t.Errorf("Set after Close failed: %v", err)
+	}
+	if _, err := cache.Get("key"); err != nil {
+		t.Errorf("Get after Close failed: %v", err)
+	}
+}
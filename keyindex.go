@@ -0,0 +1,148 @@
+package tscache
+
+import (
+	"strings"
+)
+
+// Skip list parameters for the key index
+const (
+	keyIndexMaxLevel = 32 // Maximum number of levels, enough for 4^32 keys
+	keyIndexBranch   = 4  // Each level holds on average 1/keyIndexBranch of the level below
+)
+
+// keyIndexNode represents a single key in the skip list.
+type keyIndexNode struct {
+	key  string          // Cache key stored in this node
+	next []*keyIndexNode // Successor on every level the node participates in
+}
+
+// keyIndex keeps the keys of a shard in sorted order so that all keys sharing a
+// prefix can be found without scanning the whole shard. It is implemented as a
+// skip list with one node per key.
+//
+// Time Complexity:
+//   - insert: O(log n) expected
+//   - remove: O(log n) expected
+//   - withPrefix: O(log n + k) where k is the number of matching keys
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type keyIndex struct {
+	head   *keyIndexNode // Sentinel node preceding the first key
+	level  int           // Number of levels currently in use
+	length int           // Number of indexed keys
+	seed   uint64        // State of the level generator
+}
+
+// newKeyIndex creates an empty key index.
+func newKeyIndex() *keyIndex {
+	return &keyIndex{
+		head:  &keyIndexNode{next: make([]*keyIndexNode, keyIndexMaxLevel)},
+		level: 1,
+		seed:  0x9E3779B97F4A7C15,
+	}
+}
+
+// Len returns the number of indexed keys.
+func (ki *keyIndex) Len() int {
+	return ki.length
+}
+
+// insert adds a key to the index. Inserting a key that is already indexed is a no-op.
+//
+// Parameters:
+//   - key: Cache key to index
+func (ki *keyIndex) insert(key string) {
+	var update [keyIndexMaxLevel]*keyIndexNode
+	node := ki.head
+	for level := ki.level - 1; level >= 0; level-- {
+		for node.next[level] != nil && node.next[level].key < key {
+			node = node.next[level]
+		}
+		update[level] = node
+	}
+
+	if next := node.next[0]; next != nil && next.key == key {
+		return
+	}
+
+	level := ki.randomLevel()
+	if level > ki.level {
+		for l := ki.level; l < level; l++ {
+			update[l] = ki.head
+		}
+		ki.level = level
+	}
+
+	newNode := &keyIndexNode{key: key, next: make([]*keyIndexNode, level)}
+	for l := 0; l < level; l++ {
+		newNode.next[l] = update[l].next[l]
+		update[l].next[l] = newNode
+	}
+	ki.length++
+}
+
+// remove deletes a key from the index. Removing a key that is not indexed is a no-op.
+//
+// Parameters:
+//   - key: Cache key to remove
+func (ki *keyIndex) remove(key string) {
+	var update [keyIndexMaxLevel]*keyIndexNode
+	node := ki.head
+	for level := ki.level - 1; level >= 0; level-- {
+		for node.next[level] != nil && node.next[level].key < key {
+			node = node.next[level]
+		}
+		update[level] = node
+	}
+
+	target := node.next[0]
+	if target == nil || target.key != key {
+		return
+	}
+
+	for l := 0; l < len(target.next); l++ {
+		update[l].next[l] = target.next[l]
+	}
+	for ki.level > 1 && ki.head.next[ki.level-1] == nil {
+		ki.level--
+	}
+	ki.length--
+}
+
+// withPrefix returns every indexed key starting with prefix, in sorted order.
+//
+// Parameters:
+//   - prefix: Key prefix to search for
+//
+// Returns:
+//   - []string: Matching keys
+func (ki *keyIndex) withPrefix(prefix string) []string {
+	node := ki.head
+	for level := ki.level - 1; level >= 0; level-- {
+		for node.next[level] != nil && node.next[level].key < prefix {
+			node = node.next[level]
+		}
+	}
+
+	var keys []string
+	for node = node.next[0]; node != nil && strings.HasPrefix(node.key, prefix); node = node.next[0] {
+		keys = append(keys, node.key)
+	}
+	return keys
+}
+
+// randomLevel picks the level of a new node using a xorshift generator.
+//
+// Returns:
+//   - int: Level between 1 and keyIndexMaxLevel
+func (ki *keyIndex) randomLevel() int {
+	ki.seed ^= ki.seed << 13
+	ki.seed ^= ki.seed >> 7
+	ki.seed ^= ki.seed << 17
+
+	level := 1
+	for r := ki.seed; level < keyIndexMaxLevel && r%keyIndexBranch == 0; r /= keyIndexBranch {
+		level++
+	}
+	return level
+}
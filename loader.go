@@ -0,0 +1,345 @@
+package tscache
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// LoaderFunc produces the value for a key. It is registered with WithLoader and
+// used to refresh items in the background.
+type LoaderFunc func(key string) ([]byte, error)
+
+// loadCall represents a loader invocation that is in flight for a single key.
+// Goroutines that miss on the same key while the call is running wait on it
+// and share its result instead of invoking the loader again.
+type loadCall struct {
+	wg      sync.WaitGroup // Released once the loader has returned
+	value   []byte         // Value produced by the loader
+	err     error          // Error produced by the loader
+	version uint64         // Version of the item a refresh replaces
+}
+
+// loaderPanic is the error recorded for a loader that panicked. It carries the
+// panic value and the stack of the panicking goroutine.
+type loaderPanic struct {
+	value any    // Value passed to panic
+	stack []byte // Stack trace captured when the panic was recovered
+}
+
+// Error returns the panic value followed by the stack trace of the loader.
+func (p *loaderPanic) Error() string {
+	return fmt.Sprintf("%v: %v\n\n%s", ErrLoaderPanic, p.value, p.stack)
+}
+
+// Unwrap returns ErrLoaderPanic so callers can match the error with errors.Is.
+func (p *loaderPanic) Unwrap() error {
+	return ErrLoaderPanic
+}
+
+// GetOrLoad returns the cached value for key, loading it on a miss.
+//
+// Parameters:
+//   - key: The cache key to lookup
+//   - ttl: Time to live for the loaded value (0 for no expiration)
+//   - loader: Function producing the value when the key is not cached
+//
+// Returns:
+//   - []byte: The cached or freshly loaded value
+//   - error: nil on success, the loader's error if loading failed
+//
+// Concurrent misses on the same key are coalesced: the loader is invoked exactly
+// once and every waiting caller receives the same value or error. A successfully
+// loaded value is stored with the given TTL; failed loads are not cached.
+//
+// With WithNegativeTTL, a loader returning ErrKeyNotFound (or an error wrapping it)
+// is remembered for the negative TTL, during which GetOrLoad returns ErrKeyNotFound
+// without invoking the loader again.
+//
+// With WithStaleTTL, an item that expired less than the stale TTL ago is returned
+// while loader refreshes it in the background. With WithRefreshAhead, an item read
+// in the final part of its lifetime is refreshed the same way before it expires.
+//
+// If loader panics, the panic is propagated to the caller that invoked it, while
+// callers waiting on the same load receive an error wrapping ErrLoaderPanic. A
+// panic during a background refresh is recorded as a load error and leaves the
+// current item in place.
+func (c *Cache) GetOrLoad(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	shard := c.getShard(key)
+
+	if item, _, refresh := shard.accessItem(key, true); item != nil {
+		if item.negative {
+			return nil, ErrKeyNotFound
+		}
+		if refresh {
+			shard.refresh(item, ttl, loader)
+		}
+		return shard.decode(item)
+	}
+
+	return shard.load(key, ttl, loader)
+}
+
+// GetStale retrieves a value from the cache, including values that expired less than
+// the stale TTL ago.
+//
+// Parameters:
+//   - key: The cache key to lookup
+//
+// Returns:
+//   - []byte: The cached value (nil if not found)
+//   - bool: true if the value has expired and is served from the stale window
+//   - error: nil if found, ErrKeyNotFound if the key doesn't exist or is past its stale window
+//
+// Serving a stale value, or a value in the refresh-ahead part of its lifetime, starts a
+// background refresh through the loader registered with WithLoader. Only one refresh
+// runs per key at a time; the old value keeps being served until it completes.
+func (c *Cache) GetStale(key string) ([]byte, bool, error) {
+	shard := c.getShard(key)
+
+	item, stale, refresh := shard.accessItem(key, true)
+	if item == nil || item.negative {
+		return nil, false, ErrKeyNotFound
+	}
+	if refresh {
+		shard.refreshWithLoader(item)
+	}
+
+	value, err := shard.decode(item)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, stale, nil
+}
+
+// load invokes loader for key, deduplicating concurrent calls for the same key.
+//
+// Parameters:
+//   - key: Cache key being loaded
+//   - ttl: Time to live for the loaded value
+//   - loader: Function producing the value
+//
+// Returns:
+//   - []byte: The loaded value
+//   - error: The loader's error, if any
+//
+// The first caller for a key becomes the leader and runs the loader; callers
+// arriving while it runs block until the leader finishes and share its result.
+// A panicking loader panics again in the leader once the waiters are released.
+func (s *CacheShard) load(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	s.loadMu.Lock()
+	if call, exists := s.calls[key]; exists {
+		s.loadMu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+
+	call := &loadCall{}
+	call.wg.Add(1)
+	s.calls[key] = call
+	s.loadMu.Unlock()
+
+	s.doLoad(key, ttl, loader, call, false)
+
+	var p *loaderPanic
+	if errors.As(call.err, &p) {
+		panic(p)
+	}
+	return call.value, call.err
+}
+
+// refresh reloads an item in the background unless a load for its key is already in flight.
+//
+// Parameters:
+//   - item: The item to refresh
+//   - ttl: Time to live for the reloaded value
+//   - loader: Function producing the value
+//
+// The refresh registers itself as an in-flight call, so misses on the same key wait
+// for it instead of invoking the loader again. A failed or panicking refresh leaves
+// the current item in place, and the result is dropped if the item was replaced or
+// removed while the loader ran.
+func (s *CacheShard) refresh(item *CacheItem, ttl time.Duration, loader func() ([]byte, error)) {
+	key := item.Key
+
+	s.loadMu.Lock()
+	if _, exists := s.calls[key]; exists {
+		s.loadMu.Unlock()
+		return
+	}
+
+	call := &loadCall{version: item.Version}
+	call.wg.Add(1)
+	s.calls[key] = call
+	s.loadMu.Unlock()
+
+	go s.doLoad(key, ttl, loader, call, true)
+}
+
+// refreshWithLoader refreshes an item through the loader registered with WithLoader.
+//
+// Parameters:
+//   - item: The item to refresh, reloaded with its own TTL
+//
+// This operation is a no-op if no loader is registered.
+func (s *CacheShard) refreshWithLoader(item *CacheItem) {
+	if s.loader == nil {
+		return
+	}
+
+	loader, key := s.loader, item.Key
+	s.refresh(item, item.ttl, func() ([]byte, error) {
+		return loader(key)
+	})
+}
+
+// refreshDue reports whether a live item is in the refresh-ahead part of its lifetime.
+//
+// Parameters:
+//   - item: A stored item
+//   - now: Reference time for the check
+//
+// Returns:
+//   - bool: true if less than the refresh-ahead fraction of the item's TTL remains
+//
+// The caller must hold the shard lock.
+func (s *CacheShard) refreshDue(item *CacheItem, now time.Time) bool {
+	if s.refreshAhead <= 0 || item.ttl <= 0 || item.ExpireAt.IsZero() {
+		return false
+	}
+
+	return item.ExpireAt.Sub(now) < time.Duration(float64(item.ttl)*s.refreshAhead)
+}
+
+// doLoad runs the loader on behalf of all callers waiting on call.
+//
+// Parameters:
+//   - key: Cache key being loaded
+//   - ttl: Time to live for the loaded value
+//   - loader: Function producing the value
+//   - call: In-flight call receiving the result
+//   - refresh: Whether the call replaces a value that is still cached
+//
+// A previous leader may have stored the value between this caller's miss and
+// its registration, so the shard is checked again before the loader runs. Refreshes
+// skip the check since the value they replace is still cached.
+func (s *CacheShard) doLoad(key string, ttl time.Duration, loader func() ([]byte, error), call *loadCall, refresh bool) {
+	defer func() {
+		s.loadMu.Lock()
+		delete(s.calls, key)
+		s.loadMu.Unlock()
+		call.wg.Done()
+	}()
+
+	if !refresh {
+		if value, ok, err := s.peek(key); ok {
+			call.value, call.err = value, err
+			return
+		}
+	}
+
+	call.value, call.err = invokeLoader(loader)
+
+	s.stats.mu.Lock()
+	s.stats.Loads++
+	if call.err != nil {
+		s.stats.LoadErrors++
+	}
+	s.stats.mu.Unlock()
+
+	if call.err != nil {
+		call.value = nil
+		if errors.Is(call.err, ErrKeyNotFound) && s.negativeTTL > 0 {
+			if refresh {
+				s.storeRefreshed(s.newNegativeItem(key), call.version)
+			} else {
+				s.setNegative(key)
+			}
+		}
+		return
+	}
+
+	if refresh {
+		s.storeRefreshed(s.newItem(key, call.value, ttl, time.Now()), call.version)
+		return
+	}
+	call.err = s.Set(key, call.value, ttl)
+}
+
+// storeRefreshed stores the result of a background refresh.
+//
+// Parameters:
+//   - item: Item built from the loader's result
+//   - version: Version of the item the refresh replaces
+//
+// The result is dropped if the refreshed item was deleted, invalidated or overwritten
+// while the loader ran, so a refresh never resurrects a removed key or replaces a
+// newer value. Otherwise the refreshed item keeps the idle timeout, tags and cost of
+// the item it replaces, so a refresh does not detach the key from its tags or change
+// its eviction weight.
+func (s *CacheShard) storeRefreshed(item *CacheItem, version uint64) {
+	s.mu.Lock()
+	defer s.unlock()
+
+	current, exists := s.data[item.Key]
+	if !exists || current.Version != version {
+		return
+	}
+	if !item.negative {
+		item.IdleTimeout = current.IdleTimeout
+		item.Cost = current.Cost
+		item.setTags(current.Tags)
+	}
+	s.storeLocked(item)
+}
+
+// invokeLoader runs loader, recovering from a panic.
+//
+// Parameters:
+//   - loader: Function producing the value
+//
+// Returns:
+//   - []byte: The loaded value, nil if loader panicked
+//   - error: The loader's error, or a *loaderPanic if it panicked
+func invokeLoader(loader func() ([]byte, error)) (value []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, &loaderPanic{value: r, stack: debug.Stack()}
+		}
+	}()
+
+	return loader()
+}
+
+// setNegative caches a "not found" loader result for key.
+//
+// Parameters:
+//   - key: Cache key the loader reported as missing
+//
+// The negative item holds no value and is charged the length of its key against
+// the memory limit. It replaces any item stored under key and expires after the
+// negative TTL.
+func (s *CacheShard) setNegative(key string) {
+	item := s.newNegativeItem(key)
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	s.storeLocked(item)
+}
+
+// newNegativeItem builds the item caching a "not found" loader result for key.
+//
+// Parameters:
+//   - key: Cache key the loader reported as missing
+//
+// Returns:
+//   - *CacheItem: Negative item expiring after the negative TTL
+func (s *CacheShard) newNegativeItem(key string) *CacheItem {
+	item := s.newItem(key, nil, s.negativeTTL, time.Now())
+	item.negative = true
+	item.Size = len(key)
+	return item
+}
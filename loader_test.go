@@ -0,0 +1,167 @@
+package tscache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheGetOrLoad(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	// 未命中时调用loader并缓存结果
+	value, err := cache.GetOrLoad("key1", 0, func() ([]byte, error) {
+		return toBytes("loaded"), nil
+	})
+	if err != nil {
+		t.Fatalf("GetOrLoad failed: %v", err)
+	}
+	if string(value) != "loaded" {
+		t.Errorf("GetOrLoad returned %s, want loaded", string(value))
+	}
+
+	// 命中时不再调用loader
+	value, err = cache.GetOrLoad("key1", 0, func() ([]byte, error) {
+		t.Error("loader should not be called on hit")
+		return nil, nil
+	})
+	if err != nil || string(value) != "loaded" {
+		t.Errorf("GetOrLoad hit = %s, %v", string(value), err)
+	}
+
+	stats := cache.Stats()
+	if stats.Loads != 1 {
+		t.Errorf("Loads = %d, want 1", stats.Loads)
+	}
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Hits = %d, Misses = %d, want 1 and 1", stats.Hits, stats.Misses)
+	}
+}
+
+func TestCacheGetOrLoadCoalescing(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	var calls int32
+	release := make(chan struct{})
+	loader := func() ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return toBytes("shared"), nil
+	}
+
+	// 大量goroutine同时未命中同一个key
+	var wg sync.WaitGroup
+	results := make([]string, 200)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := cache.GetOrLoad("hot", 0, loader)
+			if err != nil {
+				t.Errorf("GetOrLoad failed: %v", err)
+			}
+			results[i] = string(value)
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("loader called %d times, want 1", n)
+	}
+	for i, result := range results {
+		if result != "shared" {
+			t.Errorf("result[%d] = %s, want shared", i, result)
+		}
+	}
+}
+
+func TestCacheGetOrLoadError(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+	loadErr := errors.New("database unavailable")
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.GetOrLoad("broken", 0, func() ([]byte, error) {
+				<-release
+				return nil, loadErr
+			})
+			// 所有等待者都应收到loader的错误
+			if !errors.Is(err, loadErr) {
+				t.Errorf("GetOrLoad error = %v, want %v", err, loadErr)
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// 失败的结果不应被缓存
+	if _, err := cache.Get("broken"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("failed load should not be cached, got %v", err)
+	}
+
+	stats := cache.Stats()
+	if stats.LoadErrors != stats.Loads || stats.LoadErrors == 0 {
+		t.Errorf("Loads = %d, LoadErrors = %d", stats.Loads, stats.LoadErrors)
+	}
+}
+
+func TestCacheGetOrLoadPanic(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	leaderDone := make(chan any)
+	go func() {
+		defer func() { leaderDone <- recover() }()
+		cache.GetOrLoad("key", 0, func() ([]byte, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	// 等待中的调用者收到ErrLoaderPanic而不是空值
+	waiterErr := make(chan error)
+	go func() {
+		_, err := cache.GetOrLoad("key", 0, func() ([]byte, error) {
+			t.Error("loader should not be called while a load is in flight")
+			return nil, nil
+		})
+		waiterErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if err := <-waiterErr; !errors.Is(err, ErrLoaderPanic) {
+		t.Errorf("waiter error = %v, want ErrLoaderPanic", err)
+	}
+
+	// 调用loader的goroutine重新抛出panic
+	recovered := <-leaderDone
+	if err, ok := recovered.(error); !ok || !errors.Is(err, ErrLoaderPanic) {
+		t.Errorf("leader recovered %v, want an error wrapping ErrLoaderPanic", recovered)
+	}
+
+	// 之后的调用重新执行loader
+	value, err := cache.GetOrLoad("key", 0, func() ([]byte, error) {
+		return toBytes("loaded"), nil
+	})
+	if err != nil || string(value) != "loaded" {
+		t.Errorf("GetOrLoad after panic = %q, %v", value, err)
+	}
+	if stats := cache.Stats(); stats.Loads != 2 || stats.LoadErrors != 1 {
+		t.Errorf("Loads = %d, LoadErrors = %d; want 2, 1", stats.Loads, stats.LoadErrors)
+	}
+}
@@ -0,0 +1,165 @@
+package tscache
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheNegativeCaching(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithNegativeTTL(30*time.Millisecond))
+
+	var calls atomic.Int32
+	loader := func() ([]byte, error) {
+		calls.Add(1)
+		return nil, fmt.Errorf("user 42: %w", ErrKeyNotFound)
+	}
+
+	if _, err := cache.GetOrLoad("user:42", time.Hour, loader); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("GetOrLoad error = %v, want ErrKeyNotFound", err)
+	}
+
+	// 负缓存期间不再调用loader
+	for i := 0; i < 5; i++ {
+		if _, err := cache.GetOrLoad("user:42", time.Hour, loader); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("GetOrLoad error = %v, want ErrKeyNotFound", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader called %d times, want 1", n)
+	}
+
+	// 普通读取同样返回ErrKeyNotFound
+	if _, err := cache.Get("user:42"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get error = %v, want ErrKeyNotFound", err)
+	}
+
+	stats := cache.Stats()
+	if stats.NegativeHits != 6 || stats.Hits != 0 {
+		t.Errorf("NegativeHits = %d, Hits = %d; want 6, 0", stats.NegativeHits, stats.Hits)
+	}
+	if stats.CurrentCount != 1 || stats.CurrentSize != len("user:42") {
+		t.Errorf("CurrentCount = %d, CurrentSize = %d", stats.CurrentCount, stats.CurrentSize)
+	}
+
+	// 负缓存过期后重新调用loader
+	time.Sleep(50 * time.Millisecond)
+	cache.GetOrLoad("user:42", time.Hour, loader)
+	if n := calls.Load(); n != 2 {
+		t.Errorf("loader called %d times after negative TTL, want 2", n)
+	}
+}
+
+func TestCacheNegativeCachingDisabled(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	var calls atomic.Int32
+	loader := func() ([]byte, error) {
+		calls.Add(1)
+		return nil, ErrKeyNotFound
+	}
+
+	cache.GetOrLoad("key", time.Hour, loader)
+	cache.GetOrLoad("key", time.Hour, loader)
+	if n := calls.Load(); n != 2 {
+		t.Errorf("loader called %d times, want 2", n)
+	}
+	if stats := cache.Stats(); stats.CurrentCount != 0 {
+		t.Errorf("CurrentCount = %d, want 0", stats.CurrentCount)
+	}
+}
+
+func TestCacheNegativeEntriesAreAbsent(t *testing.T) {
+	recorder := newRemovalRecorder()
+	cache := NewCache(WithMaxSize(1024*1024), WithNegativeTTL(time.Hour), WithOnEvict(recorder.record))
+
+	// 其他错误不会被负缓存
+	cache.GetOrLoad("broken", time.Hour, func() ([]byte, error) {
+		return nil, errors.New("backend unavailable")
+	})
+	if stats := cache.Stats(); stats.CurrentCount != 0 {
+		t.Errorf("CurrentCount = %d after failed load, want 0", stats.CurrentCount)
+	}
+
+	notFound := func() ([]byte, error) { return nil, ErrKeyNotFound }
+	cache.GetOrLoad("missing", time.Hour, notFound)
+
+	if _, _, err := cache.GetStale("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetStale error = %v, want ErrKeyNotFound", err)
+	}
+	if _, _, err := cache.GetWithVersion("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetWithVersion error = %v, want ErrKeyNotFound", err)
+	}
+	if _, err := cache.TTL("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TTL error = %v, want ErrKeyNotFound", err)
+	}
+	if values := cache.GetMany([]string{"missing"}); len(values) != 0 {
+		t.Errorf("GetMany returned %d values, want 0", len(values))
+	}
+	cache.Range(func(key string, value []byte, meta ItemInfo) bool {
+		t.Errorf("Range visited negative item %s", key)
+		return true
+	})
+
+	// Add可以覆盖负缓存，且负缓存不会触发删除回调
+	if err := cache.Add("missing", toBytes("value"), 0); err != nil {
+		t.Fatalf("Add over negative item failed: %v", err)
+	}
+	if value, err := cache.Get("missing"); err != nil || string(value) != "value" {
+		t.Errorf("Get after Add = %q, %v", value, err)
+	}
+	if _, ok := recorder.reason("missing"); ok {
+		t.Error("negative items should not be reported to the removal callback")
+	}
+}
+
+func TestCacheNegativeEntriesInBatches(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithNegativeTTL(time.Hour), WithKeyIndex(true))
+	notFound := func() ([]byte, error) { return nil, ErrKeyNotFound }
+
+	cache.Set("user:1", toBytes("alice"), 0)
+	cache.GetOrLoad("user:2", time.Hour, notFound)
+
+	// GetMany与Get一致，负缓存计为NegativeHits
+	values := cache.GetMany([]string{"user:1", "user:2", "user:3"})
+	if len(values) != 1 || string(values["user:1"]) != "alice" {
+		t.Errorf("GetMany = %v, want only user:1", values)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.NegativeHits != 1 || stats.Misses != 2 {
+		t.Errorf("Hits = %d, NegativeHits = %d, Misses = %d; want 1, 1, 2", stats.Hits, stats.NegativeHits, stats.Misses)
+	}
+
+	// 删除负缓存不计入删除数量，但负缓存本身被清除
+	if removed := cache.DeleteMany([]string{"user:1", "user:2"}); removed != 1 {
+		t.Errorf("DeleteMany removed %d items, want 1", removed)
+	}
+	if stats := cache.Stats(); stats.CurrentCount != 0 {
+		t.Errorf("CurrentCount = %d after DeleteMany, want 0", stats.CurrentCount)
+	}
+
+	tests := []struct {
+		name   string
+		delete func() int
+	}{
+		{"DeletePrefix", func() int { return cache.DeletePrefix("user:") }},
+		{"DeleteMatching", func() int {
+			removed, _ := cache.DeleteMatching("user:*")
+			return removed
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache.Set("user:1", toBytes("alice"), 0)
+			cache.GetOrLoad("user:2", time.Hour, notFound)
+
+			if removed := tt.delete(); removed != 1 {
+				t.Errorf("%s removed %d items, want 1", tt.name, removed)
+			}
+			if stats := cache.Stats(); stats.CurrentCount != 0 {
+				t.Errorf("CurrentCount = %d after %s, want 0", stats.CurrentCount, tt.name)
+			}
+		})
+	}
+}
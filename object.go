@@ -0,0 +1,157 @@
+package tscache
+
+import (
+	"time"
+)
+
+// Weigher returns the number of bytes a value should be charged against the cache's memory limit.
+type Weigher[V any] func(value V) int
+
+// ObjectCache stores Go values of type V directly, without serializing them.
+// Each value is charged against the memory limit by its estimated size: the reflective
+// estimate from calculateSize by default, or a caller-supplied Weigher for types whose
+// footprint is known more precisely or is expensive to walk.
+//
+// Stored values are shared with callers, not copied; mutating a value obtained from
+// Get mutates the cached value without updating its accounted size. Compression
+// options have no effect on an ObjectCache, and removal callbacks receive a nil value.
+type ObjectCache[V any] struct {
+	cache   *Cache     // Underlying cache holding the objects
+	weigher Weigher[V] // Size estimator (nil uses calculateSize)
+}
+
+// NewObjectCache creates a new object cache.
+//
+// Parameters:
+//   - weigher: Function estimating the size of a value (nil for the reflective estimate)
+//   - opts: Functional options configuring the underlying cache, as for NewCache
+//
+// Returns:
+//   - *ObjectCache[V]: A new object cache ready for use
+//
+// Example usage:
+//
+//	sessions := NewObjectCache[*Session](nil, WithMaxSize(64*1024*1024))
+//	err := sessions.Set("abc", session, 30*time.Minute)
+//	session, err := sessions.Get("abc")
+func NewObjectCache[V any](weigher Weigher[V], opts ...Option) *ObjectCache[V] {
+	return &ObjectCache[V]{
+		cache:   NewCache(opts...),
+		weigher: weigher,
+	}
+}
+
+// Set stores a value with an optional TTL.
+//
+// Parameters:
+//   - key: The cache key
+//   - value: The value to store
+//   - ttl: Time to live duration (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success, error if operation fails
+func (oc *ObjectCache[V]) Set(key string, value V, ttl time.Duration) error {
+	shard := oc.cache.getShard(key)
+	return shard.setObject(key, value, oc.weigh(value), ttl)
+}
+
+// Get retrieves a value by key.
+//
+// Parameters:
+//   - key: The cache key to lookup
+//
+// Returns:
+//   - V: The cached value (zero value if not found)
+//   - error: nil if found, ErrKeyNotFound if not found or expired
+func (oc *ObjectCache[V]) Get(key string) (V, error) {
+	shard := oc.cache.getShard(key)
+
+	item := shard.access(key)
+	if item == nil {
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+
+	value, _ := item.Object.(V)
+	return value, nil
+}
+
+// Delete removes a value from the cache.
+//
+// Parameters:
+//   - key: The cache key to remove
+func (oc *ObjectCache[V]) Delete(key string) {
+	oc.cache.Delete(key)
+}
+
+// Clear removes all values from the cache.
+func (oc *ObjectCache[V]) Clear() {
+	oc.cache.Clear()
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (oc *ObjectCache[V]) Stats() Stats {
+	return oc.cache.Stats()
+}
+
+// Close stops the background goroutines owned by the cache.
+func (oc *ObjectCache[V]) Close() {
+	oc.cache.Close()
+}
+
+// weigh returns the accounted size of a value.
+//
+// Parameters:
+//   - value: The value to measure
+//
+// Returns:
+//   - int: Size in bytes (never negative)
+func (oc *ObjectCache[V]) weigh(value V) int {
+	var size int
+	if oc.weigher != nil {
+		size = oc.weigher(value)
+	} else {
+		size = int(calculateSize(value))
+	}
+
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+// setObject stores an unserialized value in this shard.
+//
+// Parameters:
+//   - key: Cache key
+//   - object: Value to store as-is
+//   - size: Size charged against the shard's memory limit
+//   - ttl: Time to live (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success
+func (s *CacheShard) setObject(key string, object any, size int, ttl time.Duration) error {
+	now := time.Now()
+
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = now.Add(ttl)
+	}
+
+	item := &CacheItem{
+		Key:       key,
+		Object:    object,
+		Size:      size,
+		ExpireAt:  expireAt,
+		CreatedAt: now,
+		AccessAt:  now,
+		ttl:       ttl,
+	}
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	s.storeLocked(item)
+
+	return nil
+}
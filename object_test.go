@@ -0,0 +1,94 @@
+package tscache
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type objectTestSession struct {
+	UserID int
+	Roles  []string
+	Data   map[string]string
+}
+
+func TestObjectCache(t *testing.T) {
+	sessions := NewObjectCache[*objectTestSession](nil, WithMaxSize(1024*1024))
+	defer sessions.Close()
+
+	session := &objectTestSession{UserID: 1, Roles: []string{"admin"}}
+	if err := sessions.Set("s1", session, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// 返回的是同一个对象，没有经过序列化
+	got, err := sessions.Get("s1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != session {
+		t.Error("Get should return the stored pointer")
+	}
+
+	// 使用反射估算的大小计入内存统计
+	stats := sessions.Stats()
+	if want := int(calculateSize(session)); stats.CurrentSize != want {
+		t.Errorf("CurrentSize = %d, want %d", stats.CurrentSize, want)
+	}
+
+	sessions.Delete("s1")
+	if _, err := sessions.Get("s1"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestObjectCacheWeigher(t *testing.T) {
+	weigher := func(s objectTestSession) int { return 100 }
+	sessions := NewObjectCache[objectTestSession](weigher, WithMaxSize(1000*getOptimalShardCount()))
+
+	for i := 0; i < 50; i++ {
+		sessions.Set(fmt.Sprintf("s%d", i), objectTestSession{UserID: i}, 0)
+	}
+
+	// 每个对象按Weigher计100字节，超出上限时触发淘汰
+	stats := sessions.Stats()
+	if stats.CurrentSize != stats.CurrentCount*100 {
+		t.Errorf("CurrentSize = %d, want %d", stats.CurrentSize, stats.CurrentCount*100)
+	}
+	if stats.CurrentSize > stats.MaxSize {
+		t.Errorf("CurrentSize %d exceeds MaxSize %d", stats.CurrentSize, stats.MaxSize)
+	}
+}
+
+func TestObjectCacheExpiration(t *testing.T) {
+	values := NewObjectCache[int](nil)
+
+	values.Set("answer", 42, 10*time.Millisecond)
+	if value, err := values.Get("answer"); err != nil || value != 42 {
+		t.Errorf("Get = %d, %v, want 42", value, err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := values.Get("answer"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expired Get error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestObjectCacheCyclicValue(t *testing.T) {
+	nodes := NewObjectCache[*sizeTestNode](nil, WithMaxSize(1024*1024))
+	defer nodes.Close()
+
+	// 循环链表按反射估算大小时不应栈溢出
+	a, b := &sizeTestNode{Value: 1}, &sizeTestNode{Value: 2}
+	a.Next, b.Next = b, a
+	if err := nodes.Set("ring", a, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, err := nodes.Get("ring"); err != nil || got != a {
+		t.Errorf("Get = %v, %v; want the stored node", got, err)
+	}
+	if stats := nodes.Stats(); stats.CurrentSize != int(calculateSize(a)) {
+		t.Errorf("CurrentSize = %d, want %d", stats.CurrentSize, calculateSize(a))
+	}
+}
@@ -0,0 +1,119 @@
+package tscache
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EvictionListFactory creates the eviction list of a single cache shard. It is
+// called once per shard, so every call must return a new, independent list.
+type EvictionListFactory func() EvictionList
+
+// CapacityAware is implemented by eviction lists that size their bookkeeping to
+// the memory limit of the shard they serve. The shard calls SetCapacity with its
+// memory limit in bytes right after the list is created.
+type CapacityAware interface {
+	// SetCapacity sets the capacity of the list in bytes
+	SetCapacity(capacity int)
+}
+
+// Replacer is implemented by eviction lists that can swap the item tracked for a
+// key in place. When an item is overwritten, the shard calls Replace instead of
+// removing the key and adding it again, so the list can keep the key's position
+// and bookkeeping.
+type Replacer interface {
+	// Replace swaps the item tracked for key for its replacement
+	Replace(key string, item *CacheItem)
+}
+
+// customEvictionPolicy is the policy name reported for lists set with WithEvictionList.
+const customEvictionPolicy = "custom"
+
+var (
+	policiesMu sync.RWMutex
+	policies   = map[string]EvictionListFactory{
+		EvictionLRU:     func() EvictionList { return NewLRUList() },
+		EvictionLFU:     func() EvictionList { return NewLFUList() },
+		EvictionFIFO:    func() EvictionList { return NewFIFOList() },
+		EvictionTinyLFU: func() EvictionList { return NewTinyLFUList() },
+		EvictionARC:     func() EvictionList { return NewARCList(0) },
+		EvictionSIEVE:   func() EvictionList { return NewSIEVEList() },
+		EvictionS3FIFO:  func() EvictionList { return NewS3FIFOList() },
+		EvictionGDSF:    func() EvictionList { return NewGDSFList() },
+
+		EvictionAllKeysLRU:  func() EvictionList { return NewSampledList(SampleLRU, DefaultEvictionSamples) },
+		EvictionAllKeysLFU:  func() EvictionList { return NewSampledList(SampleLFU, DefaultEvictionSamples) },
+		EvictionVolatileLRU: func() EvictionList { return NewSampledList(SampleVolatileLRU, DefaultEvictionSamples) },
+		EvictionVolatileTTL: func() EvictionList { return NewSampledList(SampleVolatileTTL, DefaultEvictionSamples) },
+	}
+)
+
+// sampledPolicies maps the names of the sampled policies to their sampling mode.
+var sampledPolicies = map[string]SampleMode{
+	EvictionAllKeysLRU:  SampleLRU,
+	EvictionAllKeysLFU:  SampleLFU,
+	EvictionVolatileLRU: SampleVolatileLRU,
+	EvictionVolatileTTL: SampleVolatileTTL,
+}
+
+// RegisterEvictionPolicy makes an eviction policy available by name to
+// WithEvictionPolicy.
+//
+// Parameters:
+//   - name: Policy name, as passed to WithEvictionPolicy
+//   - factory: Function creating the eviction list of a shard
+//
+// Policies are normally registered from an init function. RegisterEvictionPolicy
+// panics if name is empty, factory is nil or a policy with the same name, including
+// one of the built-in policies, is already registered.
+func RegisterEvictionPolicy(name string, factory func() EvictionList) {
+	if name == "" {
+		panic("tscache: RegisterEvictionPolicy with empty name")
+	}
+	if factory == nil {
+		panic("tscache: RegisterEvictionPolicy factory is nil for " + name)
+	}
+
+	policiesMu.Lock()
+	defer policiesMu.Unlock()
+
+	if _, exists := policies[name]; exists {
+		panic("tscache: RegisterEvictionPolicy called twice for " + name)
+	}
+	policies[name] = factory
+}
+
+// lookupEvictionPolicy returns the factory registered under name.
+//
+// Parameters:
+//   - name: Policy name
+//
+// Returns:
+//   - EvictionListFactory: The registered factory
+//   - error: nil if found, ErrUnknownPolicy if no policy is registered under name
+func lookupEvictionPolicy(name string) (EvictionListFactory, error) {
+	policiesMu.RLock()
+	factory, exists := policies[name]
+	policiesMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, name)
+	}
+	return factory, nil
+}
+
+// newEvictionList creates a shard's eviction list.
+//
+// Parameters:
+//   - factory: Function creating the list
+//   - capacity: Memory limit of the shard in bytes
+//
+// Returns:
+//   - EvictionList: The new list, sized to capacity if it is CapacityAware
+func newEvictionList(factory EvictionListFactory, capacity int) EvictionList {
+	list := factory()
+	if aware, ok := list.(CapacityAware); ok {
+		aware.SetCapacity(capacity)
+	}
+	return list
+}
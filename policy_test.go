@@ -0,0 +1,162 @@
+package tscache
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// countingList 统计淘汰次数并记录容量的自定义淘汰策略
+type countingList struct {
+	*LRUList
+	capacity  int
+	evictions *atomic.Int32
+}
+
+func (cl *countingList) SetCapacity(capacity int) {
+	cl.capacity = capacity
+}
+
+func (cl *countingList) RemoveLeast() string {
+	cl.evictions.Add(1)
+	return cl.LRUList.RemoveLeast()
+}
+
+var registeredEvictions atomic.Int32
+
+func init() {
+	RegisterEvictionPolicy("counting", func() EvictionList {
+		return &countingList{LRUList: NewLRUList(), evictions: &registeredEvictions}
+	})
+}
+
+func TestRegisterEvictionPolicy(t *testing.T) {
+	cache, err := NewCacheE(WithMaxSize(1024), WithEvictionPolicy("counting"))
+	if err != nil {
+		t.Fatalf("NewCacheE with registered policy: %v", err)
+	}
+	if policy := cache.Stats().EvictionPolicy; policy != "counting" {
+		t.Errorf("EvictionPolicy = %q, want counting", policy)
+	}
+
+	// 每个分片使用独立的淘汰列表，且容量为分片内存上限
+	seen := make(map[EvictionList]bool)
+	for _, shard := range cache.shards {
+		list, ok := shard.evictionList.(*countingList)
+		if !ok {
+			t.Fatalf("shard eviction list is %T, want *countingList", shard.evictionList)
+		}
+		if list.capacity != shard.maxSize {
+			t.Errorf("list capacity = %d, want %d", list.capacity, shard.maxSize)
+		}
+		if seen[list] {
+			t.Error("shards share an eviction list")
+		}
+		seen[list] = true
+	}
+
+	before := registeredEvictions.Load()
+	for i := 0; i < 1000; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), make([]byte, 64), 0)
+	}
+	if registeredEvictions.Load() == before {
+		t.Error("registered policy was not used for eviction")
+	}
+}
+
+func TestRegisterEvictionPolicyPanics(t *testing.T) {
+	factory := func() EvictionList { return NewLRUList() }
+
+	tests := []struct {
+		name    string
+		policy  string
+		factory func() EvictionList
+	}{
+		{"空名称", "", factory},
+		{"空工厂函数", "nil-factory", nil},
+		{"重复注册", "counting", factory},
+		{"覆盖内置策略", EvictionLRU, factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterEvictionPolicy should panic")
+				}
+			}()
+			RegisterEvictionPolicy(tt.policy, tt.factory)
+		})
+	}
+}
+
+func TestNewCacheEUnknownPolicy(t *testing.T) {
+	cache, err := NewCacheE(WithEvictionPolicy("INVALID"))
+	if !errors.Is(err, ErrUnknownPolicy) || cache != nil {
+		t.Errorf("NewCacheE(INVALID) = %v, %v; want nil, ErrUnknownPolicy", cache, err)
+	}
+
+	// NewCache仍然回退到LRU
+	if policy := NewCache(WithEvictionPolicy("INVALID")).Stats().EvictionPolicy; policy != EvictionLRU {
+		t.Errorf("NewCache(INVALID) policy = %q, want LRU", policy)
+	}
+
+	for _, policy := range []string{EvictionLRU, EvictionLFU, EvictionFIFO, EvictionTinyLFU, EvictionARC, EvictionSIEVE, EvictionS3FIFO, EvictionGDSF} {
+		if _, err := NewCacheE(WithEvictionPolicy(policy)); err != nil {
+			t.Errorf("NewCacheE(%s): %v", policy, err)
+		}
+	}
+}
+
+func TestWithEvictionList(t *testing.T) {
+	var evictions atomic.Int32
+	factory := func() EvictionList {
+		return &countingList{LRUList: NewLRUList(), evictions: &evictions}
+	}
+
+	cache, err := NewCacheE(WithMaxSize(1024), WithEvictionList(factory))
+	if err != nil {
+		t.Fatalf("NewCacheE with custom list: %v", err)
+	}
+	if policy := cache.Stats().EvictionPolicy; policy != "custom" {
+		t.Errorf("EvictionPolicy = %q, want custom", policy)
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), make([]byte, 64), 0)
+	}
+	if evictions.Load() == 0 {
+		t.Error("custom eviction list was not used")
+	}
+
+	// 后设置的选项生效
+	cache = NewCache(WithEvictionList(factory), WithEvictionPolicy(EvictionFIFO))
+	if _, ok := cache.shards[0].evictionList.(*FIFOList); !ok {
+		t.Errorf("eviction list is %T, want *FIFOList", cache.shards[0].evictionList)
+	}
+}
+
+func TestARCCapacityFromShard(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithEvictionPolicy(EvictionARC))
+
+	for _, shard := range cache.shards {
+		if arc := shard.evictionList.(*ARCList); arc.capacity != shard.maxSize {
+			t.Errorf("ARC capacity = %d, want %d", arc.capacity, shard.maxSize)
+		}
+	}
+
+	// 缩小容量时丢弃多余的ghost记录
+	arc := NewARCList(100)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key%d", i)
+		arc.Add(key, &CacheItem{Key: key, Size: 1})
+	}
+	for i := 0; i < 100; i++ {
+		arc.RemoveLeast()
+	}
+	arc.SetCapacity(10)
+	if ghosts := arc.weights[arcB1] + arc.weights[arcB2]; ghosts > 20 {
+		t.Errorf("ghost weight after shrinking = %d, want at most 20", ghosts)
+	}
+}
@@ -0,0 +1,92 @@
+package tscache
+
+import (
+	"strings"
+)
+
+// DeletePrefix removes every item whose key starts with prefix.
+//
+// Parameters:
+//   - prefix: Key prefix to match (an empty prefix matches every key)
+//
+// Returns:
+//   - int: Number of items removed
+//
+// Shards are processed one at a time. With WithKeyIndex enabled only matching keys
+// are visited; otherwise each shard is scanned. Removed items are reported to the
+// removal callback with the Deleted reason. Cached "not found" results under matching
+// keys are dropped without being counted.
+func (c *Cache) DeletePrefix(prefix string) int {
+	removed := 0
+	for _, shard := range c.shards {
+		removed += shard.deletePrefix(prefix, nil)
+	}
+	return removed
+}
+
+// DeleteMatching removes every item whose key matches a glob pattern.
+//
+// Parameters:
+//   - pattern: Redis-style glob pattern supporting *, ?, [...] classes and \ escapes
+//
+// Returns:
+//   - int: Number of items removed
+//   - error: ErrBadPattern if the pattern is malformed
+//
+// The literal prefix of the pattern (e.g. "user:42:" for "user:42:*") is used to
+// narrow the candidates through the key index when WithKeyIndex is enabled. As with
+// DeletePrefix, cached "not found" results are dropped without being counted.
+func (c *Cache) DeleteMatching(pattern string) (int, error) {
+	if err := validateGlob(pattern); err != nil {
+		return 0, err
+	}
+
+	prefix := globPrefix(pattern)
+	match := func(key string) bool {
+		return matchGlob(pattern, key)
+	}
+
+	removed := 0
+	for _, shard := range c.shards {
+		removed += shard.deletePrefix(prefix, match)
+	}
+	return removed, nil
+}
+
+// deletePrefix removes the items of this shard whose key starts with prefix.
+//
+// Parameters:
+//   - prefix: Key prefix to match
+//   - match: Optional additional filter applied to candidate keys (nil accepts all)
+//
+// Returns:
+//   - int: Number of items removed, not counting negative items
+func (s *CacheShard) deletePrefix(prefix string, match func(key string) bool) int {
+	s.mu.Lock()
+	defer s.unlock()
+
+	var candidates []string
+	if s.keys != nil {
+		candidates = s.keys.withPrefix(prefix)
+	} else {
+		for key := range s.data {
+			if strings.HasPrefix(key, prefix) {
+				candidates = append(candidates, key)
+			}
+		}
+	}
+
+	removed := 0
+	for _, key := range candidates {
+		if match != nil && !match(key) {
+			continue
+		}
+		if item, exists := s.data[key]; exists {
+			s.deleteLocked(key, item, Deleted)
+			if !item.negative {
+				removed++
+			}
+		}
+	}
+	return removed
+}
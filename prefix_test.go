@@ -0,0 +1,134 @@
+package tscache
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCacheDeletePrefix(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("index=%v", indexed), func(t *testing.T) {
+			recorder := newRemovalRecorder()
+			cache := NewCache(WithMaxSize(1024*1024), WithKeyIndex(indexed), WithOnEvict(recorder.record))
+
+			for i := 0; i < 10; i++ {
+				cache.Set(fmt.Sprintf("user:42:field%d", i), toBytes("value"), 0)
+				cache.Set(fmt.Sprintf("user:43:field%d", i), toBytes("value"), 0)
+			}
+			cache.Set("user:4", toBytes("value"), 0)
+
+			removed := cache.DeletePrefix("user:42:")
+			if removed != 10 {
+				t.Errorf("DeletePrefix removed %d, want 10", removed)
+			}
+
+			// 内存与数量统计同步更新
+			stats := cache.Stats()
+			if stats.CurrentCount != 11 || stats.CurrentSize != 11*len("value") {
+				t.Errorf("CurrentCount = %d, CurrentSize = %d", stats.CurrentCount, stats.CurrentSize)
+			}
+			if _, err := cache.Get("user:43:field0"); err != nil {
+				t.Errorf("user:43 keys should remain: %v", err)
+			}
+			if reason, _ := recorder.reason("user:42:field3"); reason != Deleted {
+				t.Errorf("removal reason = %v, want Deleted", reason)
+			}
+
+			// 清空后索引也应被重置
+			cache.Clear()
+			if removed := cache.DeletePrefix("user:"); removed != 0 {
+				t.Errorf("DeletePrefix after Clear removed %d, want 0", removed)
+			}
+		})
+	}
+}
+
+func TestCacheDeleteMatching(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("index=%v", indexed), func(t *testing.T) {
+			cache := NewCache(WithMaxSize(1024*1024), WithKeyIndex(indexed))
+
+			keys := []string{"page:1:header", "page:1:footer", "page:2:header", "page:10:header", "other"}
+			for _, key := range keys {
+				cache.Set(key, toBytes("value"), 0)
+			}
+
+			removed, err := cache.DeleteMatching("page:?:header")
+			if err != nil {
+				t.Fatalf("DeleteMatching failed: %v", err)
+			}
+			if removed != 2 {
+				t.Errorf("DeleteMatching removed %d, want 2", removed)
+			}
+
+			for key, want := range map[string]bool{
+				"page:1:header":  false,
+				"page:2:header":  false,
+				"page:1:footer":  true,
+				"page:10:header": true,
+				"other":          true,
+			} {
+				_, err := cache.Get(key)
+				if (err == nil) != want {
+					t.Errorf("key %s present = %v, want %v", key, err == nil, want)
+				}
+			}
+
+			if _, err := cache.DeleteMatching("page:[1"); !errors.Is(err, ErrBadPattern) {
+				t.Errorf("malformed pattern error = %v, want ErrBadPattern", err)
+			}
+		})
+	}
+}
+
+func TestKeyIndex(t *testing.T) {
+	index := newKeyIndex()
+
+	for i := 0; i < 1000; i++ {
+		index.insert(fmt.Sprintf("key:%04d", i))
+	}
+	index.insert("key:0001") // 重复插入不应产生重复key
+	if index.Len() != 1000 {
+		t.Errorf("Len = %d, want 1000", index.Len())
+	}
+
+	keys := index.withPrefix("key:01")
+	if len(keys) != 100 {
+		t.Fatalf("withPrefix returned %d keys, want 100", len(keys))
+	}
+	for i, key := range keys {
+		if want := fmt.Sprintf("key:01%02d", i); key != want {
+			t.Errorf("keys[%d] = %s, want %s (sorted order)", i, key, want)
+		}
+	}
+
+	for i := 0; i < 1000; i += 2 {
+		index.remove(fmt.Sprintf("key:%04d", i))
+	}
+	index.remove("missing")
+	if index.Len() != 500 {
+		t.Errorf("Len after remove = %d, want 500", index.Len())
+	}
+	if keys := index.withPrefix("key:01"); len(keys) != 50 {
+		t.Errorf("withPrefix after remove returned %d keys, want 50", len(keys))
+	}
+}
+
+func BenchmarkCacheDeletePrefix(b *testing.B) {
+	for _, indexed := range []bool{false, true} {
+		b.Run(fmt.Sprintf("index=%v", indexed), func(b *testing.B) {
+			cache := NewCache(WithMaxSize(1024*1024*100), WithKeyIndex(indexed))
+			for i := 0; i < 100000; i++ {
+				cache.Set(fmt.Sprintf("user:%d:profile", i), toBytes("value"), 0)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := fmt.Sprintf("user:%d:", i%100000)
+				cache.DeletePrefix(key)
+				cache.Set(key+"profile", toBytes("value"), 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newReclaimShard 创建使用毫秒级过期索引的分片，便于测试
+func newReclaimShard() *CacheShard {
+	shard := NewCacheShard(1024*1024, EvictionLRU, NewNoCompressor(), 1024*1024)
+	shard.expiry = newTimingWheel(minWheelTick, time.Now())
+	return shard
+}
+
+func TestEvictionPrefersExpired(t *testing.T) {
+	shard := newReclaimShard()
+
+	// 先写入的永久数据在LRU中最旧，随后写入的数据很快过期
+	for i := 0; i < 20; i++ {
+		shard.Set(fmt.Sprintf("persist%03d", i), make([]byte, 100), 0)
+	}
+	for i := 0; i < 20; i++ {
+		shard.Set(fmt.Sprintf("expired%03d", i), make([]byte, 100), 20*time.Millisecond)
+	}
+	itemSize := shard.data["persist000"].Size
+	shard.maxSize = shard.currentSize
+
+	time.Sleep(40 * time.Millisecond)
+
+	// 内存不足时先回收已过期的数据，不淘汰仍然有效的数据
+	for i := 0; i < 10; i++ {
+		shard.Set(fmt.Sprintf("newdata%03d", i), make([]byte, 100), 0)
+	}
+	for i := 0; i < 20; i++ {
+		if _, exists := shard.data[fmt.Sprintf("persist%03d", i)]; !exists {
+			t.Errorf("persist%03d was evicted while expired items were available", i)
+		}
+	}
+
+	stats := shard.getStats()
+	if stats.Evictions != 0 || stats.EvictedBytes != 0 {
+		t.Errorf("Evictions = %d, EvictedBytes = %d; want 0", stats.Evictions, stats.EvictedBytes)
+	}
+	if stats.Expirations != 20 || stats.ExpiredBytes != 20*itemSize {
+		t.Errorf("Expirations = %d, ExpiredBytes = %d; want 20, %d", stats.Expirations, stats.ExpiredBytes, 20*itemSize)
+	}
+
+	// 没有过期数据后按淘汰策略淘汰
+	for i := 10; i < 25; i++ {
+		shard.Set(fmt.Sprintf("newdata%03d", i), make([]byte, 100), 0)
+	}
+	stats = shard.getStats()
+	if stats.Evictions != 5 || stats.EvictedBytes != 5*itemSize {
+		t.Errorf("Evictions = %d, EvictedBytes = %d; want 5, %d", stats.Evictions, stats.EvictedBytes, 5*itemSize)
+	}
+	if _, exists := shard.data["persist000"]; exists {
+		t.Error("least recently used item should be evicted once no expired items remain")
+	}
+}
+
+func TestEvictionKeepsStaleItems(t *testing.T) {
+	shard := newReclaimShard()
+	shard.staleTTL = time.Hour
+
+	shard.Set("stale", make([]byte, 100), 10*time.Millisecond)
+	shard.Set("live", make([]byte, 100), 0)
+	shard.maxSize = shard.currentSize
+
+	time.Sleep(20 * time.Millisecond)
+
+	// stale窗口内的数据仍可被读取，不作为过期数据优先回收
+	shard.Set("new", make([]byte, 100), 0)
+	if stats := shard.getStats(); stats.Expirations != 0 || stats.Evictions != 1 {
+		t.Errorf("Expirations = %d, Evictions = %d; want 0, 1", stats.Expirations, stats.Evictions)
+	}
+}
+
+func TestStatsReclaimedBytes(t *testing.T) {
+	cache := NewCache(WithMaxSize(64 * 1024))
+
+	cache.Set("short", make([]byte, 100), 10*time.Millisecond)
+	size := cache.getShard("short").data["short"].Size
+	time.Sleep(20 * time.Millisecond)
+
+	// 读取时发现过期同样计入回收字节数
+	if _, err := cache.Get("short"); err == nil {
+		t.Fatal("expired item should not be returned")
+	}
+	if stats := cache.Stats(); stats.ExpiredBytes != size {
+		t.Errorf("ExpiredBytes = %d, want %d", stats.ExpiredBytes, size)
+	}
+
+	for i := 0; i < 2000; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), make([]byte, 100), 0)
+	}
+	stats := cache.Stats()
+	if stats.Evictions == 0 || stats.EvictedBytes < stats.Evictions*100 {
+		t.Errorf("Evictions = %d, EvictedBytes = %d", stats.Evictions, stats.EvictedBytes)
+	}
+
+	// 显式删除不计入回收字节数
+	evicted := stats.EvictedBytes
+	cache.Delete("key1999")
+	if stats := cache.Stats(); stats.EvictedBytes != evicted || stats.ExpiredBytes != size {
+		t.Errorf("Delete changed reclaimed bytes: %d, %d", stats.EvictedBytes, stats.ExpiredBytes)
+	}
+
+	cache.Clear()
+	if stats := cache.Stats(); stats.ExpiredBytes != 0 || stats.EvictedBytes != 0 {
+		t.Errorf("after Clear: ExpiredBytes = %d, EvictedBytes = %d", stats.ExpiredBytes, stats.EvictedBytes)
+	}
+}
@@ -0,0 +1,333 @@
+package tscache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitFor 轮询直到条件成立或超时
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCacheRefreshAhead(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(key string) ([]byte, error) {
+		n := calls.Add(1)
+		<-release
+		return toBytes(fmt.Sprintf("%s-v%d", key, n+1)), nil
+	}
+
+	cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithRefreshAhead(0.5))
+	cache.Set("key", toBytes("key-v1"), 100*time.Millisecond)
+
+	// 生命周期前半段读取不触发刷新
+	cache.Get("key")
+	if calls.Load() != 0 {
+		t.Fatal("read early in the lifetime should not refresh")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	// 并发读取只触发一次刷新，刷新期间继续返回旧值
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.Get("key")
+			if err != nil || string(value) != "key-v1" {
+				t.Errorf("Get during refresh = %q, %v; want key-v1", value, err)
+			}
+		}()
+	}
+	wg.Wait()
+	waitFor(t, time.Second, func() bool { return calls.Load() == 1 })
+
+	close(release)
+	waitFor(t, time.Second, func() bool {
+		value, err := cache.Get("key")
+		return err == nil && string(value) == "key-v2"
+	})
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader called %d times, want 1", n)
+	}
+
+	// 刷新后的数据沿用原TTL
+	if ttl, err := cache.TTL("key"); err != nil || ttl <= 50*time.Millisecond {
+		t.Errorf("TTL after refresh = %v, %v", ttl, err)
+	}
+}
+
+func TestCacheRefreshAheadFailure(t *testing.T) {
+	var calls atomic.Int32
+	loader := func(key string) ([]byte, error) {
+		calls.Add(1)
+		return nil, errors.New("backend unavailable")
+	}
+
+	cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithRefreshAhead(1))
+	cache.Set("key", toBytes("value"), time.Hour)
+	cache.Get("key")
+
+	waitFor(t, time.Second, func() bool { return cache.Stats().LoadErrors == 1 })
+
+	// 刷新失败时保留旧值
+	if value, err := cache.Get("key"); err != nil || string(value) != "value" {
+		t.Errorf("Get after failed refresh = %q, %v", value, err)
+	}
+}
+
+func TestCacheGetStale(t *testing.T) {
+	release := make(chan struct{})
+	loader := func(key string) ([]byte, error) {
+		<-release
+		return toBytes("fresh"), nil
+	}
+
+	cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithStaleTTL(time.Hour))
+	cache.Set("key", toBytes("old"), 20*time.Millisecond)
+
+	if value, stale, err := cache.GetStale("key"); err != nil || stale || string(value) != "old" {
+		t.Errorf("GetStale before expiry = %q, %v, %v", value, stale, err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	// 过期后返回旧值并标记为stale
+	value, stale, err := cache.GetStale("key")
+	if err != nil || !stale || string(value) != "old" {
+		t.Errorf("GetStale after expiry = %q, %v, %v; want old, true", value, stale, err)
+	}
+
+	// Get将stale数据视为不存在
+	if _, err := cache.Get("key"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get of stale item error = %v, want ErrKeyNotFound", err)
+	}
+
+	close(release)
+	waitFor(t, time.Second, func() bool {
+		value, stale, err := cache.GetStale("key")
+		return err == nil && !stale && string(value) == "fresh"
+	})
+
+	if _, _, err := cache.GetStale("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetStale(missing) error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCacheStaleWindowEnds(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithStaleTTL(30*time.Millisecond), WithCleanupInterval(10*time.Millisecond))
+	defer cache.Close()
+
+	cache.Set("key", toBytes("value"), 20*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+
+	if _, stale, err := cache.GetStale("key"); err != nil || !stale {
+		t.Errorf("GetStale in stale window = %v, %v", stale, err)
+	}
+
+	// stale窗口结束后数据被后台清理删除
+	waitFor(t, time.Second, func() bool { return cache.Stats().CurrentCount == 0 })
+	if _, _, err := cache.GetStale("key"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetStale after stale window error = %v, want ErrKeyNotFound", err)
+	}
+	if stats := cache.Stats(); stats.Expirations != 1 {
+		t.Errorf("Expirations = %d, want 1", stats.Expirations)
+	}
+}
+
+func TestCacheGetOrLoadStale(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithStaleTTL(time.Hour))
+	cache.Set("key", toBytes("old"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	loader := func() ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return toBytes("fresh"), nil
+	}
+
+	// 过期数据在刷新期间被直接返回
+	for i := 0; i < 5; i++ {
+		value, err := cache.GetOrLoad("key", time.Hour, loader)
+		if err != nil || string(value) != "old" {
+			t.Fatalf("GetOrLoad during refresh = %q, %v; want old", value, err)
+		}
+	}
+	waitFor(t, time.Second, func() bool { return calls.Load() == 1 })
+
+	close(release)
+	waitFor(t, time.Second, func() bool {
+		value, err := cache.Get("key")
+		return err == nil && string(value) == "fresh"
+	})
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader called %d times, want 1", n)
+	}
+}
+
+func TestCacheRefreshKeepsOptions(t *testing.T) {
+	loader := func(key string) ([]byte, error) {
+		return toBytes("fresh"), nil
+	}
+
+	cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithStaleTTL(time.Hour))
+	cache.SetWithOptions("key", toBytes("old"), SetOptions{
+		TTL:         20 * time.Millisecond,
+		IdleTimeout: time.Minute,
+		Tags:        []string{"group"},
+		Cost:        5,
+	})
+	time.Sleep(40 * time.Millisecond)
+
+	// 读取过期数据触发后台刷新
+	if _, stale, err := cache.GetStale("key"); err != nil || !stale {
+		t.Fatalf("GetStale after expiry = %v, %v; want stale value", stale, err)
+	}
+	waitFor(t, time.Second, func() bool {
+		value, err := cache.Get("key")
+		return err == nil && string(value) == "fresh"
+	})
+
+	// 刷新后的数据保留空闲超时、标签和成本
+	shard := cache.getShard("key")
+	shard.mu.RLock()
+	item := shard.data["key"]
+	idleTimeout, cost := item.IdleTimeout, item.Cost
+	shard.mu.RUnlock()
+	if idleTimeout != time.Minute || cost != 5 {
+		t.Errorf("IdleTimeout = %v, Cost = %v after refresh; want 1m, 5", idleTimeout, cost)
+	}
+
+	if removed := cache.InvalidateTag("group"); removed != 1 {
+		t.Errorf("InvalidateTag after refresh removed %d items, want 1", removed)
+	}
+	if _, err := cache.Get("key"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get after InvalidateTag error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCacheRefreshPanic(t *testing.T) {
+	loader := func(key string) ([]byte, error) {
+		panic("backend bug")
+	}
+
+	cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithRefreshAhead(1))
+	cache.Set("key", toBytes("value"), time.Hour)
+
+	// 后台刷新中的panic不会导致进程崩溃，记为加载错误并保留旧值
+	cache.Get("key")
+	waitFor(t, time.Second, func() bool { return cache.Stats().LoadErrors == 1 })
+
+	if value, err := cache.Get("key"); err != nil || string(value) != "value" {
+		t.Errorf("Get after panicking refresh = %q, %v", value, err)
+	}
+}
+
+func TestCacheRefreshRaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cache *Cache)
+		want   string // 刷新完成后期望的值，空字符串表示数据不存在
+	}{
+		{"Delete", func(cache *Cache) { cache.Delete("key") }, ""},
+		{"InvalidateTag", func(cache *Cache) { cache.InvalidateTag("group") }, ""},
+		{"Set", func(cache *Cache) { cache.Set("key", toBytes("newer"), time.Hour) }, "newer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			var calls atomic.Int32
+			loader := func(key string) ([]byte, error) {
+				calls.Add(1)
+				<-release
+				return toBytes("loaded"), nil
+			}
+
+			cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithStaleTTL(time.Hour))
+			cache.SetWithTags("key", toBytes("old"), 20*time.Millisecond, "group")
+			time.Sleep(40 * time.Millisecond)
+
+			// 刷新进行中数据被修改，刷新结果应被丢弃
+			if _, stale, err := cache.GetStale("key"); err != nil || !stale {
+				t.Fatalf("GetStale after expiry = %v, %v; want stale value", stale, err)
+			}
+			waitFor(t, time.Second, func() bool { return calls.Load() == 1 })
+			tt.modify(cache)
+
+			close(release)
+			shard := cache.getShard("key")
+			waitFor(t, time.Second, func() bool {
+				shard.loadMu.Lock()
+				defer shard.loadMu.Unlock()
+				return len(shard.calls) == 0
+			})
+
+			value, err := cache.Get("key")
+			if tt.want == "" {
+				if !errors.Is(err, ErrKeyNotFound) {
+					t.Errorf("Get after refresh = %q, %v; want ErrKeyNotFound", value, err)
+				}
+				return
+			}
+			if err != nil || string(value) != tt.want {
+				t.Errorf("Get after refresh = %q, %v; want %q", value, err, tt.want)
+			}
+			if ttl, err := cache.TTL("key"); err != nil || ttl < 50*time.Minute {
+				t.Errorf("TTL after refresh = %v, %v; want the TTL of the newer value", ttl, err)
+			}
+		})
+	}
+}
+
+func TestCacheGetManyRefresh(t *testing.T) {
+	var mu sync.Mutex
+	loaded := make(map[string]int)
+	loader := func(key string) ([]byte, error) {
+		mu.Lock()
+		loaded[key]++
+		mu.Unlock()
+		return toBytes(key + "-fresh"), nil
+	}
+
+	cache := NewCache(WithMaxSize(1024*1024), WithLoader(loader), WithRefreshAhead(0.5), WithStaleTTL(time.Hour))
+	cache.Set("ahead", toBytes("old"), 200*time.Millisecond)
+	cache.Set("stale", toBytes("old"), 30*time.Millisecond)
+	cache.Set("fresh", toBytes("old"), time.Hour)
+	time.Sleep(110 * time.Millisecond)
+
+	// 批量读取与Get一样触发提前刷新和过期数据的后台刷新
+	values := cache.GetMany([]string{"ahead", "stale", "fresh"})
+	if string(values["ahead"]) != "old" || string(values["fresh"]) != "old" {
+		t.Errorf("GetMany = %q, want the cached values", values)
+	}
+	if _, exists := values["stale"]; exists {
+		t.Error("GetMany should not return stale values")
+	}
+
+	waitFor(t, time.Second, func() bool {
+		values := cache.GetMany([]string{"ahead", "stale"})
+		return string(values["ahead"]) == "ahead-fresh" && string(values["stale"]) == "stale-fresh"
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	if loaded["fresh"] != 0 || loaded["ahead"] == 0 || loaded["stale"] == 0 {
+		t.Errorf("loader calls = %v, want refreshes of ahead and stale only", loaded)
+	}
+}
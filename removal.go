@@ -0,0 +1,91 @@
+package tscache
+
+// RemovalReason describes why an item left the cache.
+type RemovalReason int
+
+// Removal reasons reported to the callback registered with WithOnEvict
+const (
+	// Evicted indicates the eviction policy removed the item to free memory
+	Evicted RemovalReason = iota
+	// Expired indicates the item's TTL elapsed
+	Expired
+	// Deleted indicates the item was removed explicitly
+	Deleted
+	// Replaced indicates a new value was stored under the same key
+	Replaced
+	// Cleared indicates the whole cache was cleared
+	Cleared
+)
+
+// String returns the name of the removal reason.
+func (r RemovalReason) String() string {
+	switch r {
+	case Evicted:
+		return "Evicted"
+	case Expired:
+		return "Expired"
+	case Deleted:
+		return "Deleted"
+	case Replaced:
+		return "Replaced"
+	case Cleared:
+		return "Cleared"
+	default:
+		return "Unknown"
+	}
+}
+
+// RemovalFunc is invoked for every item that leaves the cache.
+// The value is passed decompressed; it must not be modified.
+type RemovalFunc func(key string, value []byte, reason RemovalReason)
+
+// removal records an item removed while the shard lock was held, so that the
+// removal callback can be invoked after the lock has been released.
+type removal struct {
+	key        string        // Cache key of the removed item
+	value      []byte        // Stored value (may be compressed)
+	compressed bool          // Whether value is compressed
+	reason     RemovalReason // Why the item was removed
+}
+
+// recordRemoval queues an item for the removal callback.
+//
+// Parameters:
+//   - key: Cache key of the removed item
+//   - item: The removed item, captured before it is modified
+//   - reason: Why the item was removed
+//
+// The caller must hold the shard lock. This is a no-op when no callback is configured.
+func (s *CacheShard) recordRemoval(key string, item *CacheItem, reason RemovalReason) {
+	if s.onEvict == nil || item.negative {
+		return
+	}
+
+	s.pending = append(s.pending, removal{
+		key:        key,
+		value:      item.Value,
+		compressed: item.Compressed,
+		reason:     reason,
+	})
+}
+
+// unlock releases the shard lock and then reports the removals queued while it was held.
+//
+// Running the callback outside the lock allows it to call back into the cache.
+func (s *CacheShard) unlock() {
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	for _, r := range pending {
+		value := r.value
+		if r.compressed {
+			decompressedValue, err := s.compressor.Decompress(r.value)
+			if err != nil {
+				continue
+			}
+			value = decompressedValue
+		}
+		s.onEvict(r.key, value, r.reason)
+	}
+}
@@ -0,0 +1,154 @@
+package tscache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// removalRecorder 记录每个key第一次触发删除回调的情况
+type removalRecorder struct {
+	mu      sync.Mutex
+	reasons map[string]RemovalReason
+	values  map[string]string
+}
+
+func newRemovalRecorder() *removalRecorder {
+	return &removalRecorder{
+		reasons: make(map[string]RemovalReason),
+		values:  make(map[string]string),
+	}
+}
+
+func (r *removalRecorder) record(key string, value []byte, reason RemovalReason) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.reasons[key]; exists {
+		return
+	}
+	r.reasons[key] = reason
+	r.values[key] = string(value)
+}
+
+func (r *removalRecorder) reason(key string) (RemovalReason, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reason, ok := r.reasons[key]
+	return reason, ok
+}
+
+func TestCacheOnEvictReasons(t *testing.T) {
+	recorder := newRemovalRecorder()
+	cache := NewCache(WithMaxSize(1024*1024), WithOnEvict(recorder.record))
+
+	// 删除
+	cache.Set("deleted", toBytes("v1"), 0)
+	cache.Delete("deleted")
+
+	// 替换
+	cache.Set("replaced", toBytes("old"), 0)
+	cache.Set("replaced", toBytes("new"), 0)
+
+	// 过期
+	cache.Set("expired", toBytes("v3"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	cache.Get("expired")
+
+	// 清空
+	cache.Set("cleared", toBytes("v4"), 0)
+	cache.Clear()
+
+	expected := map[string]RemovalReason{
+		"deleted":  Deleted,
+		"replaced": Replaced,
+		"expired":  Expired,
+		"cleared":  Cleared,
+	}
+	for key, want := range expected {
+		got, ok := recorder.reason(key)
+		if !ok {
+			t.Errorf("no removal reported for %s", key)
+			continue
+		}
+		if got != want {
+			t.Errorf("reason for %s = %v, want %v", key, got, want)
+		}
+	}
+
+	// 替换时回调收到旧值
+	if recorder.values["replaced"] != "old" {
+		t.Errorf("replaced value = %s, want old", recorder.values["replaced"])
+	}
+}
+
+func TestCacheOnEvictEviction(t *testing.T) {
+	recorder := newRemovalRecorder()
+	cache := NewCache(
+		WithMaxSize(1024),
+		WithCompressor(NewGzipCompressor()),
+		WithCompressSize(16),
+		WithOnEvict(recorder.record),
+	)
+
+	for i := 0; i < 200; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), toBytes(strings.Repeat("x", 64)), 0)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	evicted := 0
+	for key, reason := range recorder.reasons {
+		if reason != Evicted {
+			t.Errorf("reason for %s = %v, want Evicted", key, reason)
+		}
+		// 压缩的数据在回调中应已解压
+		if recorder.values[key] != strings.Repeat("x", 64) {
+			t.Errorf("value for %s was not decompressed", key)
+		}
+		evicted++
+	}
+	if evicted != cache.Stats().Evictions {
+		t.Errorf("callbacks = %d, Evictions = %d", evicted, cache.Stats().Evictions)
+	}
+}
+
+func TestCacheOnEvictReentrant(t *testing.T) {
+	var cache *Cache
+	done := make(chan struct{})
+
+	// 回调中访问缓存不应死锁
+	cache = NewCache(WithMaxSize(1024*1024), WithOnEvict(func(key string, value []byte, reason RemovalReason) {
+		if reason == Deleted {
+			cache.Set("archived:"+key, value, 0)
+		}
+	}))
+
+	go func() {
+		cache.Set("key", toBytes("value"), 0)
+		cache.Delete("key")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("callback deadlocked")
+	}
+
+	value, err := cache.Get("archived:key")
+	if err != nil || string(value) != "value" {
+		t.Errorf("archived value = %s, %v", string(value), err)
+	}
+}
+
+func TestRemovalReasonString(t *testing.T) {
+	if Evicted.String() != "Evicted" || Cleared.String() != "Cleared" {
+		t.Error("unexpected RemovalReason names")
+	}
+	if RemovalReason(100).String() != "Unknown" {
+		t.Error("unknown reasons should be reported as Unknown")
+	}
+}
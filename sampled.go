@@ -0,0 +1,201 @@
+package tscache
+
+import (
+	"math/rand/v2"
+)
+
+// SampleMode selects how a SampledList picks its victim among the sampled items.
+type SampleMode int
+
+// Sampling modes of SampledList
+const (
+	SampleLRU         SampleMode = iota // Evict the least recently accessed item
+	SampleLFU                           // Evict the least frequently accessed item
+	SampleVolatileLRU                   // Evict the least recently accessed item with an expiration
+	SampleVolatileTTL                   // Evict the item with an expiration that expires first
+)
+
+// DefaultEvictionSamples is the number of items a SampledList inspects per eviction
+// unless configured otherwise with WithEvictionSamples.
+const DefaultEvictionSamples = 5
+
+// volatileDrawFactor bounds the draws of volatile modes, which skip items without
+// an expiration, to this multiple of the sample size.
+const volatileDrawFactor = 4
+
+// SampledList implements approximate eviction in the style of Redis: instead of
+// keeping items ordered, every eviction inspects a few randomly drawn items and
+// evicts the best candidate among them by AccessAt, AccessCount or expiration time.
+// The list only keeps a slice of item references and an index into it, so it needs
+// far less memory per item than the linked-list policies, at the price of evicting
+// an item that is merely likely, not certain, to be the least valuable one. More
+// samples bring the choice closer to the exact policy.
+//
+// The volatile modes only consider items with a TTL or idle timeout. When none of
+// the drawn items has one, they fall back to the least recently accessed drawn item
+// so the shard can still honor its memory limit.
+//
+// Time Complexity:
+//   - Add: O(1)
+//   - Remove: O(1) with hash map lookup
+//   - Update: O(1)
+//   - RemoveLeast: O(samples)
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type SampledList struct {
+	mode    SampleMode     // Criterion used to pick the victim
+	samples int            // Number of candidates inspected per eviction
+	items   []*CacheItem   // Tracked items in arbitrary order
+	index   map[string]int // Position of each key in items
+	rng     *rand.Rand     // Source of the random samples
+}
+
+// NewSampledList creates a new sampled eviction list.
+//
+// Parameters:
+//   - mode: Criterion used to pick the victim among the samples
+//   - samples: Number of items inspected per eviction (DefaultEvictionSamples if less than 1)
+//
+// Returns:
+//   - *SampledList: A new sampled list ready for use
+func NewSampledList(mode SampleMode, samples int) *SampledList {
+	if samples < 1 {
+		samples = DefaultEvictionSamples
+	}
+
+	sl := &SampledList{
+		mode:    mode,
+		samples: samples,
+		rng:     rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+	sl.Clear()
+	return sl
+}
+
+// Add inserts a new item or replaces the tracked item for an existing key.
+//
+// Parameters:
+//   - key: Cache key identifier
+//   - item: Cache item to add or update
+func (sl *SampledList) Add(key string, item *CacheItem) {
+	if i, exists := sl.index[key]; exists {
+		sl.items[i] = item
+		return
+	}
+
+	sl.index[key] = len(sl.items)
+	sl.items = append(sl.items, item)
+}
+
+// Remove deletes an item from the sampled list.
+//
+// Parameters:
+//   - key: Cache key to remove
+//
+// The last item takes the place of the removed one.
+func (sl *SampledList) Remove(key string) {
+	i, exists := sl.index[key]
+	if !exists {
+		return
+	}
+
+	last := len(sl.items) - 1
+	if i != last {
+		sl.items[i] = sl.items[last]
+		sl.index[sl.items[i].Key] = i
+	}
+	sl.items[last] = nil
+	sl.items = sl.items[:last]
+	delete(sl.index, key)
+}
+
+// Update replaces the tracked item for key.
+//
+// Parameters:
+//   - key: Cache key to update
+//   - item: Updated cache item
+//
+// Access times and counts are read from the item when sampling, so no reordering
+// is needed.
+func (sl *SampledList) Update(key string, item *CacheItem) {
+	if i, exists := sl.index[key]; exists {
+		sl.items[i] = item
+	}
+}
+
+// RemoveLeast evicts the best candidate among randomly drawn items.
+//
+// Returns:
+//   - string: Key of the evicted item, empty string if list is empty
+//
+// Lists holding no more items than the sample size inspect every item.
+func (sl *SampledList) RemoveLeast() string {
+	n := len(sl.items)
+	if n == 0 {
+		return ""
+	}
+
+	volatile := sl.mode == SampleVolatileLRU || sl.mode == SampleVolatileTTL
+	draws := sl.samples
+	if n <= sl.samples {
+		draws = n
+	} else if volatile {
+		draws *= volatileDrawFactor
+	}
+
+	best, fallback, found := -1, -1, 0
+	for d := 0; d < draws && found < sl.samples; d++ {
+		i := d
+		if n > sl.samples {
+			i = sl.rng.IntN(n)
+		}
+
+		item := sl.items[i]
+		if volatile && item.deadline().IsZero() {
+			if fallback < 0 || item.AccessAt.Before(sl.items[fallback].AccessAt) {
+				fallback = i
+			}
+			continue
+		}
+
+		found++
+		if best < 0 || sl.less(item, sl.items[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		best = fallback
+	}
+
+	key := sl.items[best].Key
+	sl.Remove(key)
+	return key
+}
+
+// Clear removes all items from the sampled list.
+func (sl *SampledList) Clear() {
+	sl.items = nil
+	sl.index = make(map[string]int)
+}
+
+// less reports whether a is a better eviction candidate than b.
+//
+// Parameters:
+//   - a: Candidate item
+//   - b: Current best candidate
+//
+// Returns:
+//   - bool: true if a should be evicted before b
+func (sl *SampledList) less(a, b *CacheItem) bool {
+	switch sl.mode {
+	case SampleLFU:
+		if a.AccessCount != b.AccessCount {
+			return a.AccessCount < b.AccessCount
+		}
+	case SampleVolatileTTL:
+		if deadlineA, deadlineB := a.deadline(), b.deadline(); !deadlineA.Equal(deadlineB) {
+			return deadlineA.Before(deadlineB)
+		}
+	}
+	return a.AccessAt.Before(b.AccessAt)
+}
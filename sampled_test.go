@@ -0,0 +1,219 @@
+package tscache
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSampledListModes(t *testing.T) {
+	base := time.Now()
+	newItems := func() []*CacheItem {
+		return []*CacheItem{
+			{Key: "old", AccessAt: base, AccessCount: 9},
+			{Key: "rare", AccessAt: base.Add(time.Second), AccessCount: 1},
+			{Key: "expiring", AccessAt: base.Add(2 * time.Second), AccessCount: 5, ExpireAt: base.Add(time.Minute)},
+			{Key: "idle", AccessAt: base.Add(3 * time.Second), AccessCount: 5, IdleTimeout: time.Hour},
+		}
+	}
+
+	// 数据量不超过采样数时检查全部数据，结果是确定的
+	tests := []struct {
+		name string
+		mode SampleMode
+		want []string
+	}{
+		{"allkeys-lru", SampleLRU, []string{"old", "rare", "expiring", "idle"}},
+		{"allkeys-lfu", SampleLFU, []string{"rare", "expiring", "idle", "old"}},
+		{"volatile-lru", SampleVolatileLRU, []string{"expiring", "idle", "old", "rare"}},
+		{"volatile-ttl", SampleVolatileTTL, []string{"expiring", "idle", "old", "rare"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSampledList(tt.mode, 0)
+			for _, item := range newItems() {
+				sl.Add(item.Key, item)
+			}
+
+			for _, want := range tt.want {
+				if removed := sl.RemoveLeast(); removed != want {
+					t.Errorf("RemoveLeast() = %q, want %q", removed, want)
+				}
+			}
+			if removed := sl.RemoveLeast(); removed != "" {
+				t.Errorf("RemoveLeast() on empty list = %q", removed)
+			}
+		})
+	}
+}
+
+func TestSampledListRemove(t *testing.T) {
+	sl := NewSampledList(SampleLRU, 3)
+	if sl.samples != 3 {
+		t.Errorf("samples = %d, want 3", sl.samples)
+	}
+
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("key%d", i)
+		sl.Add(key, &CacheItem{Key: key})
+	}
+
+	// 删除后由末尾数据填补空位，索引保持一致
+	sl.Remove("key0")
+	sl.Remove("key5")
+	sl.Remove("key9")
+	sl.Remove("nonexistent") // 不应该出错
+	sl.Update("nonexistent", &CacheItem{})
+
+	if len(sl.items) != 7 || len(sl.index) != 7 {
+		t.Fatalf("tracking %d items with %d index entries, want 7", len(sl.items), len(sl.index))
+	}
+	for key, i := range sl.index {
+		if sl.items[i].Key != key {
+			t.Errorf("index[%s] = %d points to %s", key, i, sl.items[i].Key)
+		}
+	}
+
+	// 重复添加只替换数据项
+	replacement := &CacheItem{Key: "key1"}
+	sl.Add("key1", replacement)
+	if len(sl.items) != 7 || sl.items[sl.index["key1"]] != replacement {
+		t.Error("Add of a tracked key should replace its item")
+	}
+
+	sl.Clear()
+	if removed := sl.RemoveLeast(); removed != "" {
+		t.Errorf("RemoveLeast() after Clear = %q", removed)
+	}
+}
+
+func TestSampledListApproximation(t *testing.T) {
+	const size = 10000
+	base := time.Now()
+
+	// 统计被淘汰数据的访问时间排名，采样越多越接近精确LRU
+	meanRank := func(samples int) float64 {
+		sl := NewSampledList(SampleLRU, samples)
+		for i := 0; i < size; i++ {
+			key := strconv.Itoa(i)
+			sl.Add(key, &CacheItem{Key: key, AccessAt: base.Add(time.Duration(i) * time.Millisecond)})
+		}
+
+		total := 0
+		for i := 0; i < 1000; i++ {
+			rank, _ := strconv.Atoi(sl.RemoveLeast())
+			total += rank
+		}
+		return float64(total) / 1000 / size
+	}
+
+	five, thirtyTwo := meanRank(5), meanRank(32)
+	t.Logf("mean rank of evicted items: 5 samples %.3f, 32 samples %.3f", five, thirtyTwo)
+	if five > 0.25 {
+		t.Errorf("mean rank with 5 samples = %.3f, want at most 0.25", five)
+	}
+	if thirtyTwo >= five {
+		t.Errorf("more samples should evict older items: %.3f >= %.3f", thirtyTwo, five)
+	}
+}
+
+func TestSampledVolatileFallback(t *testing.T) {
+	sl := NewSampledList(SampleVolatileTTL, 2)
+
+	// 没有设置过期时间的数据时，回退为淘汰最久未访问的数据以遵守内存上限
+	base := time.Now()
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key%d", i)
+		sl.Add(key, &CacheItem{Key: key, AccessAt: base.Add(time.Duration(i) * time.Second)})
+	}
+	for i := 0; i < 100; i++ {
+		if removed := sl.RemoveLeast(); removed == "" {
+			t.Fatalf("RemoveLeast() returned empty with %d items left", 100-i)
+		}
+	}
+}
+
+func TestSampledCacheIntegration(t *testing.T) {
+	for _, policy := range []string{EvictionAllKeysLRU, EvictionAllKeysLFU, EvictionVolatileLRU, EvictionVolatileTTL} {
+		cache, err := NewCacheE(WithMaxSize(64*1024), WithEvictionPolicy(policy))
+		if err != nil {
+			t.Fatalf("NewCacheE(%s): %v", policy, err)
+		}
+		if sl := cache.shards[0].evictionList.(*SampledList); sl.samples != DefaultEvictionSamples {
+			t.Errorf("%s: samples = %d, want %d", policy, sl.samples, DefaultEvictionSamples)
+		}
+
+		for i := 0; i < 2000; i++ {
+			cache.Set(fmt.Sprintf("key%d", i), make([]byte, 100), time.Duration(i%2)*time.Hour)
+		}
+		if stats := cache.Stats(); stats.Evictions == 0 || stats.CurrentSize > stats.MaxSize {
+			t.Errorf("%s: Evictions = %d, CurrentSize = %d, MaxSize = %d", policy, stats.Evictions, stats.CurrentSize, stats.MaxSize)
+		}
+	}
+
+	// 采样数足够多时volatile策略只淘汰设置了过期时间的数据
+	cache := NewCache(WithMaxSize(64*1024), WithEvictionPolicy(EvictionVolatileTTL), WithEvictionSamples(1000))
+	if sl := cache.shards[0].evictionList.(*SampledList); sl.samples != 1000 {
+		t.Errorf("samples = %d, want 1000", sl.samples)
+	}
+	for i := 0; i < 50; i++ {
+		cache.Set(fmt.Sprintf("persistent%d", i), make([]byte, 100), 0)
+	}
+	for i := 0; i < 1000; i++ {
+		cache.Set(fmt.Sprintf("volatile%d", i), make([]byte, 100), time.Hour)
+	}
+	for i := 0; i < 50; i++ {
+		if _, err := cache.Get(fmt.Sprintf("persistent%d", i)); err != nil {
+			t.Errorf("persistent%d was evicted under volatile-ttl", i)
+		}
+	}
+}
+
+func BenchmarkEvictionListMemory(b *testing.B) {
+	lists := []struct {
+		name    string
+		newList func() EvictionList
+	}{
+		{"LRU", func() EvictionList { return NewLRUList() }},
+		{"LFU", func() EvictionList { return NewLFUList() }},
+		{"allkeys-lru", func() EvictionList { return NewSampledList(SampleLRU, DefaultEvictionSamples) }},
+	}
+
+	// 每次操作加入一个新数据项，B/op即每个数据项的额外内存开销
+	for _, tt := range lists {
+		b.Run(tt.name, func(b *testing.B) {
+			items := make([]*CacheItem, b.N)
+			for i := range items {
+				items[i] = &CacheItem{Key: strconv.Itoa(i)}
+			}
+			list := tt.newList()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				list.Add(items[i].Key, items[i])
+			}
+		})
+	}
+}
+
+func BenchmarkSampledEvict(b *testing.B) {
+	for _, samples := range []int{5, 10, 32} {
+		b.Run(fmt.Sprintf("samples=%d", samples), func(b *testing.B) {
+			sl := NewSampledList(SampleLRU, samples)
+			now := time.Now()
+			for i := 0; i < 1000000; i++ {
+				key := strconv.Itoa(i)
+				sl.Add(key, &CacheItem{Key: key, AccessAt: now.Add(time.Duration(i))})
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := sl.RemoveLeast()
+				sl.Add(key, &CacheItem{Key: key, AccessAt: now.Add(time.Duration(i))})
+			}
+		})
+	}
+}
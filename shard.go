@@ -7,10 +7,16 @@ import (
 
 // ShardStats holds statistics for a single cache shard
 type ShardStats struct {
-	mu        sync.RWMutex // Protects concurrent access to shard statistics
-	Hits      int          // Number of successful cache hits in this shard
-	Misses    int          // Number of cache misses in this shard
-	Evictions int          // Number of items evicted in this shard
+	mu           sync.RWMutex // Protects concurrent access to shard statistics
+	Hits         int          // Number of successful cache hits in this shard
+	Misses       int          // Number of cache misses in this shard
+	Evictions    int          // Number of items evicted in this shard
+	Loads        int          // Number of loader invocations in this shard
+	LoadErrors   int          // Number of loader invocations that returned an error
+	Expirations  int          // Number of items removed because their TTL elapsed
+	NegativeHits int          // Number of lookups answered by a cached "not found" result
+	ExpiredBytes int          // Bytes reclaimed by removing expired items
+	EvictedBytes int          // Bytes reclaimed by policy evictions
 }
 
 // ShardStatsSnapshot represents a snapshot of shard statistics at a point in time
@@ -18,6 +24,12 @@ type ShardStatsSnapshot struct {
 	Hits         int // Number of successful cache hits in this shard
 	Misses       int // Number of cache misses in this shard
 	Evictions    int // Number of items evicted in this shard
+	Loads        int // Number of loader invocations in this shard
+	LoadErrors   int // Number of loader invocations that returned an error
+	Expirations  int // Number of items removed because their TTL elapsed
+	NegativeHits int // Number of lookups answered by a cached "not found" result
+	ExpiredBytes int // Bytes reclaimed by removing expired items
+	EvictedBytes int // Bytes reclaimed by policy evictions
 	CurrentCount int // Current number of items in this shard
 	CurrentSize  int // Current memory usage of this shard in bytes
 }
@@ -26,36 +38,88 @@ type ShardStatsSnapshot struct {
 // Each shard maintains its own data storage, eviction list, and synchronization mechanisms.
 // This design reduces lock contention by distributing cache operations across multiple shards.
 type CacheShard struct {
-	maxSize        int                   // Maximum memory usage for this shard in bytes
-	evictionPolicy string                // Eviction policy: "LRU", "LFU", or "FIFO"
-	data           map[string]*CacheItem // Hash map storing the actual cache data
-	evictionList   EvictionList          // Eviction policy implementation for managing item priorities
-	mu             sync.RWMutex          // Read-write mutex for thread-safe access
-	stats          *ShardStats           // Shard-specific statistics
-	currentSize    int                   // Current memory usage of this shard in bytes
-	currentCount   int                   // Current number of items in this shard
-	compressor     Compressor            // Compression algorithm
-	compressSize   int                   // Compression size threshold
+	maxSize        int                            // Maximum memory usage for this shard in bytes
+	evictionPolicy string                         // Eviction policy name, see the Eviction* constants
+	data           map[string]*CacheItem          // Hash map storing the actual cache data
+	evictionList   EvictionList                   // Eviction policy implementation for managing item priorities
+	mu             sync.RWMutex                   // Read-write mutex for thread-safe access
+	stats          *ShardStats                    // Shard-specific statistics
+	currentSize    int                            // Current memory usage of this shard in bytes
+	currentCount   int                            // Current number of items in this shard
+	compressor     Compressor                     // Compression algorithm
+	compressSize   int                            // Compression size threshold
+	loadMu         sync.Mutex                     // Protects the in-flight loader calls
+	calls          map[string]*loadCall           // In-flight loader calls keyed by cache key
+	expiry         *timingWheel                   // Expiration index for items with a TTL
+	janitorStop    chan struct{}                  // Closed to stop the background expiration sweeper
+	janitorDone    chan struct{}                  // Closed once the background sweeper has exited
+	onEvict        RemovalFunc                    // Callback invoked when items leave the shard
+	pending        []removal                      // Removals waiting to be reported once the lock is released
+	version        uint64                         // Last version assigned to a stored item
+	keys           *keyIndex                      // Sorted key index for prefix lookups (nil when disabled)
+	tags           map[string]map[string]struct{} // Keys attached to each tag
+	loader         LoaderFunc                     // Loader used to refresh items in the background (nil disables)
+	refreshAhead   float64                        // Final fraction of an item's lifetime in which reads trigger a refresh
+	staleTTL       time.Duration                  // Period after expiration during which items are retained as stale
+	negativeTTL    time.Duration                  // TTL of cached "not found" loader results (0 disables)
 }
 
 // CacheItem represents a single cached entry with metadata for eviction and expiration.
 // Items store the actual value along with timing information and compression status.
 type CacheItem struct {
-	Key         string    `json:"key"`          // Cache key identifier
-	Value       []byte    `json:"value"`        // Cached value (may be compressed)
-	Size        int       `json:"size"`         // Memory size of the item in bytes
-	ExpireAt    time.Time `json:"expire_at"`    // Expiration timestamp (zero value = no expiration)
-	CreatedAt   time.Time `json:"created_at"`   // Creation timestamp
-	AccessAt    time.Time `json:"access_at"`    // Last access timestamp (for LRU)
-	AccessCount int       `json:"access_count"` // Access frequency counter (for LFU)
-	Compressed  bool      `json:"compressed"`   // Whether the value is compressed
+	Key         string        `json:"key"`          // Cache key identifier
+	Value       []byte        `json:"value"`        // Cached value (may be compressed)
+	Size        int           `json:"size"`         // Memory size of the item in bytes
+	ExpireAt    time.Time     `json:"expire_at"`    // Expiration timestamp (zero value = no expiration)
+	CreatedAt   time.Time     `json:"created_at"`   // Creation timestamp
+	AccessAt    time.Time     `json:"access_at"`    // Last access timestamp (for LRU)
+	AccessCount int           `json:"access_count"` // Access frequency counter (for LFU)
+	Compressed  bool          `json:"compressed"`   // Whether the value is compressed
+	Object      any           `json:"-"`            // Unserialized value stored by ObjectCache
+	Version     uint64        `json:"version"`      // Write version, increasing with every store in the shard
+	Tags        []string      `json:"tags"`         // Tags the item belongs to, for group invalidation
+	IdleTimeout time.Duration `json:"idle_timeout"` // Inactivity period after which the item expires (0 = none)
+	Cost        float64       `json:"cost"`         // Recompute cost weighed by cost-aware eviction (0 = default of 1)
+
+	timer    *wheelTimer   // Position in the shard's expiration index (nil without TTL)
+	ttl      time.Duration // TTL the item was stored with, reused when it is refreshed
+	negative bool          // Whether the item records that the loader found no value
+}
+
+// isExpired reports whether the item's TTL or idle timeout has elapsed at the given time.
+//
+// Parameters:
+//   - now: Reference time for the check
+//
+// Returns:
+//   - bool: true if the item has an effective expiration time that lies before now
+func (item *CacheItem) isExpired(now time.Time) bool {
+	deadline := item.deadline()
+	return !deadline.IsZero() && now.After(deadline)
+}
+
+// deadline returns the effective expiration time of the item, which is the earlier of
+// its absolute expiration and the end of its idle period.
+//
+// Returns:
+//   - time.Time: Effective expiration time (zero value = no expiration)
+func (item *CacheItem) deadline() time.Time {
+	if item.IdleTimeout <= 0 {
+		return item.ExpireAt
+	}
+
+	idle := item.AccessAt.Add(item.IdleTimeout)
+	if item.ExpireAt.IsZero() || idle.Before(item.ExpireAt) {
+		return idle
+	}
+	return item.ExpireAt
 }
 
 // NewCacheShard creates a new cache shard with specified limits and eviction policy.
 //
 // Parameters:
 //   - maxSize: Maximum memory usage for this shard in bytes
-//   - evictionPolicy: Eviction strategy ("LRU", "LFU", or "FIFO")
+//   - evictionPolicy: Eviction strategy ("LRU", "LFU", "FIFO", "TinyLFU", "ARC", "SIEVE", "S3FIFO", "GDSF", a sampled policy or a registered policy)
 //   - compressor: Compression algorithm
 //   - compressSize: Compression size threshold
 //
@@ -65,30 +129,39 @@ type CacheItem struct {
 // The shard initializes with the appropriate eviction list implementation based on the policy.
 // Invalid policies default to LRU for consistent behavior.
 func NewCacheShard(maxSize int, evictionPolicy string, compressor Compressor, compressSize int) *CacheShard {
-	shard := &CacheShard{
+	factory, err := lookupEvictionPolicy(evictionPolicy)
+	if err != nil {
+		// Default to LRU for unknown policies
+		evictionPolicy = EvictionLRU
+		factory, _ = lookupEvictionPolicy(evictionPolicy)
+	}
+
+	return newCacheShard(maxSize, evictionPolicy, factory, compressor, compressSize)
+}
+
+// newCacheShard creates a new cache shard using the given eviction list factory.
+//
+// Parameters:
+//   - maxSize: Maximum memory usage for this shard in bytes
+//   - evictionPolicy: Policy name reported in statistics
+//   - factory: Function creating the shard's eviction list
+//   - compressor: Compression algorithm
+//   - compressSize: Compression size threshold
+//
+// Returns:
+//   - *CacheShard: A new initialized cache shard
+func newCacheShard(maxSize int, evictionPolicy string, factory EvictionListFactory, compressor Compressor, compressSize int) *CacheShard {
+	return &CacheShard{
 		maxSize:        maxSize,
 		evictionPolicy: evictionPolicy,
+		evictionList:   newEvictionList(factory, maxSize),
 		data:           make(map[string]*CacheItem),
 		stats:          &ShardStats{},
 		compressor:     compressor,
 		compressSize:   compressSize,
+		calls:          make(map[string]*loadCall),
+		expiry:         newTimingWheel(defaultWheelTick, time.Now()),
 	}
-
-	// Initialize the appropriate eviction list based on policy
-	switch evictionPolicy {
-	case EvictionLRU:
-		shard.evictionList = NewLRUList()
-	case EvictionLFU:
-		shard.evictionList = NewLFUList()
-	case EvictionFIFO:
-		shard.evictionList = NewFIFOList()
-	default:
-		// Default to LRU for unknown policies
-		shard.evictionList = NewLRUList()
-		shard.evictionPolicy = EvictionLRU
-	}
-
-	return shard
 }
 
 // Set stores a key-value pair in this shard with optional TTL and automatic compression.
@@ -108,8 +181,56 @@ func NewCacheShard(maxSize int, evictionPolicy string, compressor Compressor, co
 // - Eviction list management
 // - Statistics updates
 func (s *CacheShard) Set(key string, value []byte, ttl time.Duration) error {
+	item := s.newItem(key, value, ttl, time.Now())
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	s.storeLocked(item)
+
+	return nil
+}
+
+// Get retrieves a value from the shard by key, handling expiration and access tracking.
+//
+// Parameters:
+//   - key: Cache key to lookup
+//
+// Returns:
+//   - []byte: The cached value (decompressed if necessary)
+//   - error: nil if found, ErrKeyNotFound if not found or expired
+//
+// The method handles:
+// - Expiration checking and cleanup
+// - Automatic decompression
+// - Access statistics updates
+// - Eviction list updates for access tracking
+func (s *CacheShard) Get(key string) ([]byte, error) {
+	item, stale, refresh := s.accessItem(key, false)
+	if refresh {
+		s.refreshWithLoader(item)
+	}
+	if item == nil || stale || item.negative {
+		return nil, ErrKeyNotFound
+	}
+
+	return s.decode(item)
+}
+
+// newItem builds a cache item for value, compressing it when it exceeds the threshold.
+//
+// Parameters:
+//   - key: Cache key
+//   - value: Raw value to store
+//   - ttl: Time to live (0 for no expiration)
+//   - now: Creation time of the item
+//
+// Returns:
+//   - *CacheItem: A new item ready to be stored
+//
+// Compression happens here, outside the shard lock.
+func (s *CacheShard) newItem(key string, value []byte, ttl time.Duration, now time.Time) *CacheItem {
 	var (
-		now        = time.Now()
 		size       = len(value)
 		finalValue = value
 		compressed = false
@@ -130,98 +251,287 @@ func (s *CacheShard) Set(key string, value []byte, ttl time.Duration) error {
 		expireAt = now.Add(ttl)
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return &CacheItem{
+		Key:         key,
+		Value:       finalValue,
+		Size:        size,
+		ExpireAt:    expireAt,
+		CreatedAt:   now,
+		AccessAt:    now,
+		AccessCount: 0,
+		Compressed:  compressed,
+		ttl:         ttl,
+	}
+}
+
+// storeLocked inserts an item, replacing any item previously stored under the same key.
+//
+// Parameters:
+//   - item: The item to store
+//
+// A replaced item hands its creation time, access count and expiration timer over to the
+// new item, which receives a new version. Stored items are never modified in their Value, Object or Compressed fields,
+// which allows readers to use them after releasing the lock. The caller must hold the
+// shard lock; eviction runs before returning if the shard exceeds its memory limit.
+//
+// Eviction lists implementing Replacer swap a replaced item in place; other lists see
+// the key removed and added again.
+func (s *CacheShard) storeLocked(item *CacheItem) {
+	key := item.Key
 
+	var replacer Replacer
 	if oldItem, exists := s.data[key]; exists {
-		oldSize := oldItem.Size
-		s.currentSize -= oldSize
-		s.evictionList.Remove(key)
-
-		oldItem.Value = finalValue
-		oldItem.Size = size
-		oldItem.ExpireAt = expireAt
-		oldItem.AccessAt = now
-		oldItem.Compressed = compressed
-
-		s.currentSize += size
-		s.evictionList.Add(key, oldItem)
-	} else {
-		item := &CacheItem{
-			Key:         key,
-			Value:       finalValue,
-			Size:        size,
-			ExpireAt:    expireAt,
-			CreatedAt:   now,
-			AccessAt:    now,
-			AccessCount: 0,
-			Compressed:  compressed,
+		s.recordRemoval(key, oldItem, Replaced)
+
+		s.currentSize -= oldItem.Size
+		if r, ok := s.evictionList.(Replacer); ok {
+			replacer = r
+		} else {
+			s.evictionList.Remove(key)
 		}
+		s.unindexTagsLocked(oldItem)
 
-		s.data[key] = item
-		s.currentSize += size
+		item.CreatedAt = oldItem.CreatedAt
+		item.AccessCount = oldItem.AccessCount
+		item.timer = oldItem.timer
+	} else {
 		s.currentCount++
+		if s.keys != nil {
+			s.keys.insert(key)
+		}
+	}
+
+	s.version++
+	item.Version = s.version
+
+	s.data[key] = item
+	s.currentSize += item.Size
+	if replacer != nil {
+		replacer.Replace(key, item)
+	} else {
 		s.evictionList.Add(key, item)
 	}
+	s.indexTagsLocked(item)
+	s.scheduleExpiry(item)
 	s.evictIfNeeded(0)
-
-	return nil
 }
 
-// Get retrieves a value from the shard by key, handling expiration and access tracking.
+// lookupLocked returns the live item stored under key, removing it if it has expired.
 //
 // Parameters:
 //   - key: Cache key to lookup
+//   - now: Reference time for the expiration check
 //
 // Returns:
-//   - []byte: The cached value (decompressed if necessary)
-//   - error: nil if found, ErrKeyNotFound if not found or expired
+//   - *CacheItem: The stored item, nil if not found or expired
+//   - bool: true if a live item was found
 //
-// The method handles:
-// - Expiration checking and cleanup
-// - Automatic decompression
-// - Access statistics updates
-// - Eviction list updates for access tracking
-func (s *CacheShard) Get(key string) ([]byte, error) {
-	s.mu.RLock()
+// The caller must hold the shard lock. Statistics other than expirations are not updated.
+func (s *CacheShard) lookupLocked(key string, now time.Time) (*CacheItem, bool) {
 	item, exists := s.data[key]
-	s.mu.RUnlock()
+	if !exists {
+		return nil, false
+	}
 
+	if s.expireLocked(key, item, now) || item.negative {
+		return nil, false
+	}
+
+	return item, true
+}
+
+// expireLocked reports whether an item has expired, removing it unless it is still
+// within the stale window configured with WithStaleTTL.
+//
+// Parameters:
+//   - key: Cache key of the item
+//   - item: The item currently stored under key
+//   - now: Reference time for the expiration check
+//
+// Returns:
+//   - bool: true if the item has expired, whether it was removed or retained as stale
+//
+// The caller must hold the shard lock. Removed items are counted as expirations.
+func (s *CacheShard) expireLocked(key string, item *CacheItem, now time.Time) bool {
+	if !item.isExpired(now) {
+		return false
+	}
+	if s.isStale(item, now) {
+		return true
+	}
+
+	s.deleteLocked(key, item, Expired)
+
+	s.stats.mu.Lock()
+	s.stats.Expirations++
+	s.stats.mu.Unlock()
+	return true
+}
+
+// isStale reports whether an expired item is still within the stale window.
+//
+// Parameters:
+//   - item: A stored item
+//   - now: Reference time for the check
+//
+// Returns:
+//   - bool: true if the item has expired less than staleTTL ago
+func (s *CacheShard) isStale(item *CacheItem, now time.Time) bool {
+	return s.staleTTL > 0 && !item.negative && item.isExpired(now) && !item.isExpired(now.Add(-s.staleTTL))
+}
+
+// access looks up a live item, recording the hit or miss and updating access tracking.
+//
+// Parameters:
+//   - key: Cache key to lookup
+//
+// Returns:
+//   - *CacheItem: The stored item, nil if not found or expired
+//
+// Expired items found during the lookup are removed immediately unless they are
+// retained as stale.
+func (s *CacheShard) access(key string) *CacheItem {
+	item, stale, _ := s.accessItem(key, false)
+	if stale || (item != nil && item.negative) {
+		return nil
+	}
+	return item
+}
+
+// accessItem looks up an item, recording the hit or miss and updating access tracking.
+//
+// Parameters:
+//   - key: Cache key to lookup
+//   - allowStale: Whether the caller serves stale items (counted as hits rather than misses)
+//
+// Returns:
+//   - *CacheItem: The stored item, nil if not found or expired and not retained as stale
+//   - bool: true if the returned item is stale
+//   - bool: true if the item should be refreshed in the background
+//
+// A refresh is due when a stale item is found or when a live item is read in the final
+// part of its lifetime configured with WithRefreshAhead. Stale items neither renew their
+// idle timeout nor update the eviction order. Negative items are returned as they are and
+// counted as negative hits; callers must treat them as missing.
+func (s *CacheShard) accessItem(key string, allowStale bool) (*CacheItem, bool, bool) {
+	var reads readStats
+
+	s.mu.Lock()
+	item, stale, refresh := s.accessLocked(key, time.Now(), allowStale, &reads)
+	s.unlock()
+
+	s.recordReads(reads)
+	return item, stale, refresh
+}
+
+// readStats counts the outcomes of lookups made under a single lock acquisition.
+type readStats struct {
+	hits         int // Lookups that found a live item, or a stale item the caller serves
+	misses       int // Lookups that found nothing usable
+	negativeHits int // Lookups answered by a cached "not found" result
+}
+
+// accessLocked implements accessItem for a caller holding the shard lock.
+//
+// Parameters:
+//   - key: Cache key to lookup
+//   - now: Reference time for expiration and access tracking
+//   - allowStale: Whether the caller serves stale items (counted as hits rather than misses)
+//   - reads: Receives the outcome of the lookup
+//
+// Returns:
+//   - *CacheItem: The stored item, nil if not found or expired and not retained as stale
+//   - bool: true if the returned item is stale
+//   - bool: true if the item should be refreshed in the background
+func (s *CacheShard) accessLocked(key string, now time.Time, allowStale bool, reads *readStats) (*CacheItem, bool, bool) {
+	item, exists := s.data[key]
 	if !exists {
-		s.stats.mu.Lock()
-		s.stats.Misses++
-		s.stats.mu.Unlock()
-		return nil, ErrKeyNotFound
+		reads.misses++
+		return nil, false, false
 	}
 
 	// Check if the item has expired
-	if !item.ExpireAt.IsZero() && time.Now().After(item.ExpireAt) {
-		go s.Delete(key)
+	if s.expireLocked(key, item, now) {
+		stale := s.data[key] == item
+		if stale && allowStale {
+			reads.hits++
+		} else {
+			reads.misses++
+		}
 
-		s.stats.mu.Lock()
-		s.stats.Misses++
-		s.stats.mu.Unlock()
-		return nil, ErrKeyNotFound
+		if !stale {
+			return nil, false, false
+		}
+		return item, true, true
 	}
 
-	s.mu.Lock()
-	item.AccessAt = time.Now()
+	if item.negative {
+		reads.negativeHits++
+		return item, false, false
+	}
+
+	item.AccessAt = now
 	item.AccessCount++
 	s.evictionList.Update(key, item)
-	s.mu.Unlock()
+	reads.hits++
 
+	return item, false, s.refreshDue(item, now)
+}
+
+// recordReads adds the outcomes of lookups to the shard statistics.
+//
+// Parameters:
+//   - reads: Outcomes to record
+func (s *CacheShard) recordReads(reads readStats) {
 	s.stats.mu.Lock()
-	s.stats.Hits++
+	s.stats.Hits += reads.hits
+	s.stats.Misses += reads.misses
+	s.stats.NegativeHits += reads.negativeHits
 	s.stats.mu.Unlock()
+}
 
+// decode returns the raw value of an item, decompressing it if necessary.
+//
+// Parameters:
+//   - item: A stored item
+//
+// Returns:
+//   - []byte: The original value
+//   - error: The compressor's error, if decompression fails
+func (s *CacheShard) decode(item *CacheItem) ([]byte, error) {
 	if item.Compressed {
-		decompressedValue, err := s.compressor.Decompress(item.Value)
-		return decompressedValue, err
+		return s.compressor.Decompress(item.Value)
 	}
 
 	return item.Value, nil
 }
 
+// peek returns the value stored for key without updating statistics or access tracking.
+//
+// Parameters:
+//   - key: Cache key to lookup
+//
+// Returns:
+//   - []byte: The cached value (decompressed if necessary)
+//   - bool: true if a live value was found and could be decoded, or a live negative item was found
+//   - error: ErrKeyNotFound if the live item is a negative item
+func (s *CacheShard) peek(key string) ([]byte, bool, error) {
+	s.mu.RLock()
+	item, exists := s.data[key]
+	live := exists && !item.isExpired(time.Now())
+	s.mu.RUnlock()
+
+	if !live {
+		return nil, false, nil
+	}
+	if item.negative {
+		return nil, true, ErrKeyNotFound
+	}
+
+	value, err := s.decode(item)
+	return value, err == nil, nil
+}
+
 // Delete removes a key-value pair from the shard and updates all related structures.
 //
 // Parameters:
@@ -234,7 +544,7 @@ func (s *CacheShard) Get(key string) ([]byte, error) {
 // - Statistics updates
 func (s *CacheShard) Delete(key string) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	defer s.unlock()
 
 	// Find the item to delete
 	item, exists := s.data[key]
@@ -242,11 +552,75 @@ func (s *CacheShard) Delete(key string) {
 		return
 	}
 
-	// Remove from all data structures
-	delete(s.data, key)        // Remove from hash map
-	s.currentSize -= item.Size // Update memory accounting
-	s.currentCount--           // Update item count
+	s.deleteLocked(key, item, Deleted)
+}
+
+// deleteLocked removes an item from every shard structure, including the eviction list.
+//
+// Parameters:
+//   - key: Cache key to remove
+//   - item: The item currently stored under key
+//   - reason: Why the item is being removed
+//
+// The caller must hold the shard lock.
+func (s *CacheShard) deleteLocked(key string, item *CacheItem, reason RemovalReason) {
 	s.evictionList.Remove(key) // Remove from eviction list
+	s.removeLocked(key, item, reason)
+}
+
+// removeLocked removes an item from the hash map and updates memory accounting.
+//
+// Parameters:
+//   - key: Cache key to remove
+//   - item: The item currently stored under key
+//   - reason: Why the item is being removed
+//
+// The caller must hold the shard lock. The eviction list is left untouched so that
+// callers which obtained the key from the eviction list itself do not remove it twice.
+func (s *CacheShard) removeLocked(key string, item *CacheItem, reason RemovalReason) {
+	s.recordRemoval(key, item, reason)
+
+	delete(s.data, key)         // Remove from hash map
+	s.currentSize -= item.Size  // Update memory accounting
+	s.currentCount--            // Update item count
+	s.expiry.remove(item.timer) // Remove from expiration index
+	item.timer = nil
+	if s.keys != nil {
+		s.keys.remove(key) // Remove from key index
+	}
+	s.unindexTagsLocked(item) // Remove from tag index
+
+	// Account for the memory reclaimed by expiration and eviction
+	switch reason {
+	case Expired:
+		s.stats.mu.Lock()
+		s.stats.ExpiredBytes += item.Size
+		s.stats.mu.Unlock()
+	case Evicted:
+		s.stats.mu.Lock()
+		s.stats.EvictedBytes += item.Size
+		s.stats.mu.Unlock()
+	}
+}
+
+// scheduleExpiry registers an item's effective expiration time in the shard's expiration index.
+//
+// Parameters:
+//   - item: Item whose ExpireAt or IdleTimeout has been set or changed
+//
+// Items without an expiration time are removed from the index, and items retained as stale
+// are scheduled for the end of the stale window. The caller must hold the shard lock.
+// Renewing an idle timeout on access only moves the deadline later, so it does not reschedule:
+// the sweeper reschedules items that are still live when their timer fires.
+func (s *CacheShard) scheduleExpiry(item *CacheItem) {
+	deadline := item.deadline()
+	if deadline.IsZero() {
+		s.expiry.remove(item.timer)
+		item.timer = nil
+		return
+	}
+
+	item.timer = s.expiry.schedule(item.timer, item.Key, deadline.Add(s.staleTTL))
 }
 
 // Clear removes all items from the shard and resets its state.
@@ -254,19 +628,37 @@ func (s *CacheShard) Delete(key string) {
 // This operation is atomic and efficiently clears all shard data structures.
 func (s *CacheShard) Clear() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	defer s.unlock()
+
+	// Report every item to the removal callback before dropping them
+	if s.onEvict != nil {
+		for key, item := range s.data {
+			s.recordRemoval(key, item, Cleared)
+		}
+	}
 
 	// Clear all data structures
 	s.data = make(map[string]*CacheItem) // Create new empty map
 	s.currentSize = 0                    // Reset memory accounting
 	s.currentCount = 0                   // Reset item count
 	s.evictionList.Clear()               // Clear eviction list
+	s.expiry.clear()                     // Clear expiration index
+	if s.keys != nil {
+		s.keys = newKeyIndex() // Clear key index
+	}
+	s.tags = nil // Clear tag index
 
 	// Reset shard statistics
 	s.stats.mu.Lock()
 	s.stats.Hits = 0
 	s.stats.Misses = 0
 	s.stats.Evictions = 0
+	s.stats.Loads = 0
+	s.stats.LoadErrors = 0
+	s.stats.Expirations = 0
+	s.stats.NegativeHits = 0
+	s.stats.ExpiredBytes = 0
+	s.stats.EvictedBytes = 0
 	s.stats.mu.Unlock()
 }
 
@@ -276,9 +668,22 @@ func (s *CacheShard) Clear() {
 //   - newItemSize: Size of a new item being added (for pre-eviction planning)
 //
 // This method enforces memory limits by repeatedly evicting items until the shard
-// is within its memory budget. It only considers memory-based eviction currently.
+// is within its memory budget. Expired items found through the expiration index are
+// reclaimed first and counted as expirations; live items are only evicted by the
+// policy if that does not free enough memory. Items retained for their stale window
+// are still considered live.
 func (s *CacheShard) evictIfNeeded(newItemSize int) {
-	for s.maxSize > 0 && s.currentSize+newItemSize > s.maxSize {
+	if s.maxSize <= 0 || s.currentSize+newItemSize <= s.maxSize {
+		return
+	}
+
+	if removed := s.removeExpiredLocked(time.Now()); removed > 0 {
+		s.stats.mu.Lock()
+		s.stats.Expirations += removed
+		s.stats.mu.Unlock()
+	}
+
+	for s.currentSize+newItemSize > s.maxSize {
 		if !s.evictOne() {
 			break
 		}
@@ -299,9 +704,7 @@ func (s *CacheShard) evictOne() bool {
 	}
 
 	if item, exists := s.data[keyToEvict]; exists {
-		delete(s.data, keyToEvict)
-		s.currentSize -= item.Size
-		s.currentCount--
+		s.removeLocked(keyToEvict, item, Evicted)
 
 		s.stats.mu.Lock()
 		s.stats.Evictions++
@@ -324,6 +727,12 @@ func (s *CacheShard) getStats() ShardStatsSnapshot {
 	hits := s.stats.Hits
 	misses := s.stats.Misses
 	evictions := s.stats.Evictions
+	loads := s.stats.Loads
+	loadErrors := s.stats.LoadErrors
+	expirations := s.stats.Expirations
+	negativeHits := s.stats.NegativeHits
+	expiredBytes := s.stats.ExpiredBytes
+	evictedBytes := s.stats.EvictedBytes
 	s.stats.mu.RUnlock()
 
 	s.mu.RLock()
@@ -335,6 +744,12 @@ func (s *CacheShard) getStats() ShardStatsSnapshot {
 		Hits:         hits,
 		Misses:       misses,
 		Evictions:    evictions,
+		Loads:        loads,
+		LoadErrors:   loadErrors,
+		Expirations:  expirations,
+		NegativeHits: negativeHits,
+		ExpiredBytes: expiredBytes,
+		EvictedBytes: evictedBytes,
 		CurrentCount: currentCount,
 		CurrentSize:  currentSize,
 	}
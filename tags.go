@@ -0,0 +1,154 @@
+package tscache
+
+import (
+	"slices"
+	"time"
+)
+
+// tagEntryOverhead approximates the bytes used by one tag index entry in addition to
+// the tag string itself (map bucket slot, key reference and set header).
+const tagEntryOverhead = 48
+
+// SetWithTags stores a key-value pair and attaches it to one or more tags.
+//
+// Parameters:
+//   - key: The cache key (must be non-empty string)
+//   - value: The value to store
+//   - ttl: Time to live duration (0 for no expiration)
+//   - tags: Tags the item belongs to (duplicates are ignored)
+//
+// Returns:
+//   - error: nil on success, error if operation fails
+//
+// Tags replace those of any previous item stored under key; a plain Set removes them.
+// The memory used by the tag index is charged to the item and counts against the
+// cache budget.
+func (c *Cache) SetWithTags(key string, value []byte, ttl time.Duration, tags ...string) error {
+	return c.SetWithOptions(key, value, SetOptions{TTL: ttl, Tags: tags})
+}
+
+// InvalidateTag removes every item attached to tag.
+//
+// Parameters:
+//   - tag: The tag to invalidate
+//
+// Returns:
+//   - int: Number of items removed
+//
+// Shards are processed one at a time. Removed items are reported to the removal
+// callback with the Deleted reason.
+func (c *Cache) InvalidateTag(tag string) int {
+	removed := 0
+	for _, shard := range c.shards {
+		removed += shard.invalidateTag(tag)
+	}
+	return removed
+}
+
+// invalidateTag removes the items of this shard attached to tag.
+//
+// Parameters:
+//   - tag: The tag to invalidate
+//
+// Returns:
+//   - int: Number of items removed
+func (s *CacheShard) invalidateTag(tag string) int {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	members := s.tags[tag]
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+
+	removed := 0
+	for _, key := range keys {
+		item := s.data[key]
+
+		// Items that already expired are removed as such and not counted, and
+		// stale copies must not be served after the invalidation either
+		if s.expireLocked(key, item, now) {
+			if s.data[key] == item {
+				s.deleteLocked(key, item, Deleted)
+			}
+			continue
+		}
+
+		s.deleteLocked(key, item, Deleted)
+		removed++
+	}
+	return removed
+}
+
+// setTags attaches tags to an item that has not been stored yet and charges their
+// index memory to the item's size.
+//
+// Parameters:
+//   - tags: Tags the item belongs to (duplicates are ignored)
+func (item *CacheItem) setTags(tags []string) {
+	item.Size -= item.tagSize()
+	item.Tags = nil
+
+	for _, tag := range tags {
+		if !slices.Contains(item.Tags, tag) {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+	item.Size += item.tagSize()
+}
+
+// tagSize returns the memory charged for the item's tag index entries.
+//
+// Returns:
+//   - int: Size in bytes
+func (item *CacheItem) tagSize() int {
+	size := 0
+	for _, tag := range item.Tags {
+		size += len(tag) + tagEntryOverhead
+	}
+	return size
+}
+
+// indexTagsLocked adds an item to the tag index of the shard.
+//
+// Parameters:
+//   - item: A stored item
+//
+// The caller must hold the shard lock.
+func (s *CacheShard) indexTagsLocked(item *CacheItem) {
+	if len(item.Tags) == 0 {
+		return
+	}
+	if s.tags == nil {
+		s.tags = make(map[string]map[string]struct{})
+	}
+
+	for _, tag := range item.Tags {
+		members, exists := s.tags[tag]
+		if !exists {
+			members = make(map[string]struct{})
+			s.tags[tag] = members
+		}
+		members[item.Key] = struct{}{}
+	}
+}
+
+// unindexTagsLocked removes an item from the tag index of the shard, dropping tags
+// that no longer have any members.
+//
+// Parameters:
+//   - item: An item leaving the shard or being replaced
+//
+// The caller must hold the shard lock.
+func (s *CacheShard) unindexTagsLocked(item *CacheItem) {
+	for _, tag := range item.Tags {
+		members := s.tags[tag]
+		delete(members, item.Key)
+		if len(members) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCacheInvalidateTag(t *testing.T) {
+	recorder := newRemovalRecorder()
+	cache := NewCache(WithMaxSize(1024*1024), WithOnEvict(recorder.record))
+
+	for i := 0; i < 20; i++ {
+		cache.SetWithTags(fmt.Sprintf("fragment%d", i), toBytes("html"), 0, "product:1", "page")
+	}
+	cache.SetWithTags("other", toBytes("html"), 0, "product:2", "page")
+	cache.Set("untagged", toBytes("html"), 0)
+
+	removed := cache.InvalidateTag("product:1")
+	if removed != 20 {
+		t.Errorf("InvalidateTag removed %d, want 20", removed)
+	}
+	if reason, _ := recorder.reason("fragment7"); reason != Deleted {
+		t.Errorf("removal reason = %v, want Deleted", reason)
+	}
+	if _, err := cache.Get("other"); err != nil {
+		t.Errorf("item with another tag should remain: %v", err)
+	}
+
+	// 已失效的tag再次失效不应删除任何数据
+	if removed := cache.InvalidateTag("product:1"); removed != 0 {
+		t.Errorf("second InvalidateTag removed %d, want 0", removed)
+	}
+
+	if removed := cache.InvalidateTag("page"); removed != 1 {
+		t.Errorf("InvalidateTag(page) removed %d, want 1", removed)
+	}
+	if _, err := cache.Get("untagged"); err != nil {
+		t.Errorf("untagged item should remain: %v", err)
+	}
+}
+
+func TestCacheTagsReplaced(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.SetWithTags("key", toBytes("value"), 0, "a", "a", "b")
+	// 普通Set会清除原有tag
+	cache.Set("key", toBytes("value"), 0)
+	if removed := cache.InvalidateTag("a"); removed != 0 {
+		t.Errorf("InvalidateTag after Set removed %d, want 0", removed)
+	}
+
+	cache.SetWithTags("key", toBytes("value"), 0, "c")
+	if removed := cache.InvalidateTag("b"); removed != 0 {
+		t.Errorf("InvalidateTag(b) removed %d, want 0", removed)
+	}
+	if removed := cache.InvalidateTag("c"); removed != 1 {
+		t.Errorf("InvalidateTag(c) removed %d, want 1", removed)
+	}
+
+	// 计数器更新保留tag
+	cache.SetWithTags("counter", toBytes("1"), 0, "counters")
+	cache.Incr("counter", 0)
+	if removed := cache.InvalidateTag("counters"); removed != 1 {
+		t.Errorf("InvalidateTag(counters) removed %d, want 1", removed)
+	}
+}
+
+func TestCacheTagsMemory(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.SetWithTags("key", toBytes("value"), 0, "tag1", "tag2", "tag1")
+	want := len("value") + 2*(len("tag1")+tagEntryOverhead)
+	if size := cache.Stats().CurrentSize; size != want {
+		t.Errorf("CurrentSize = %d, want %d", size, want)
+	}
+
+	cache.Delete("key")
+	if size := cache.Stats().CurrentSize; size != 0 {
+		t.Errorf("CurrentSize after Delete = %d, want 0", size)
+	}
+	if tags := len(cache.getShard("key").tags); tags != 0 {
+		t.Errorf("tag index holds %d tags after Delete, want 0", tags)
+	}
+}
+
+func TestCacheTagsCleanup(t *testing.T) {
+	// 淘汰时清理tag索引
+	t.Run("evict", func(t *testing.T) {
+		cache := NewCache(WithMaxSize(1000))
+		for i := 0; i < 50; i++ {
+			cache.SetWithTags(fmt.Sprintf("key%d", i), toBytes("value"), 0, fmt.Sprintf("tag%d", i))
+		}
+
+		for _, shard := range cache.shards {
+			shard.mu.RLock()
+			if len(shard.tags) != len(shard.data) {
+				t.Errorf("tag index holds %d tags for %d items", len(shard.tags), len(shard.data))
+			}
+			shard.mu.RUnlock()
+		}
+	})
+
+	// 过期时清理tag索引
+	t.Run("expire", func(t *testing.T) {
+		cache := NewCache(WithMaxSize(1024*1024), WithCleanupInterval(10*time.Millisecond))
+		defer cache.Close()
+
+		cache.SetWithTags("key", toBytes("value"), 20*time.Millisecond, "tag")
+		time.Sleep(100 * time.Millisecond)
+
+		shard := cache.getShard("key")
+		shard.mu.RLock()
+		defer shard.mu.RUnlock()
+		if len(shard.tags) != 0 {
+			t.Errorf("tag index holds %d tags after expiry, want 0", len(shard.tags))
+		}
+	})
+
+	// 清空时清理tag索引
+	t.Run("clear", func(t *testing.T) {
+		cache := NewCache(WithMaxSize(1024 * 1024))
+		cache.SetWithTags("key", toBytes("value"), 0, "tag")
+		cache.Clear()
+
+		if removed := cache.InvalidateTag("tag"); removed != 0 {
+			t.Errorf("InvalidateTag after Clear removed %d, want 0", removed)
+		}
+	})
+}
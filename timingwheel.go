@@ -0,0 +1,243 @@
+package tscache
+
+import (
+	"container/list"
+	"time"
+)
+
+// Timing wheel geometry. Each level has 64 slots, and every slot of a level spans
+// 64 slots of the level below it, so four levels cover 64^4 ticks before timers
+// spill into the overflow list.
+const (
+	wheelBits   = 6              // Bits of the tick consumed by each level
+	wheelSlots  = 1 << wheelBits // Number of slots per level
+	wheelMask   = wheelSlots - 1 // Mask selecting a slot within a level
+	wheelLevels = 4              // Number of wheel levels
+	wheelSpan   = 1 << (wheelBits * wheelLevels)
+)
+
+// Bounds for the tick duration of a shard's timing wheel.
+const (
+	minWheelTick     = time.Millisecond // Finest supported expiration granularity
+	defaultWheelTick = time.Second      // Granularity used when no sweeper is configured
+)
+
+// wheelTimer tracks the position of a single cache item inside the timing wheel.
+type wheelTimer struct {
+	key    string        // Cache key of the scheduled item
+	expire int64         // Absolute tick at which the item expires
+	bucket *list.List    // Slot currently holding the timer (nil when not scheduled)
+	elem   *list.Element // Element of the timer within its slot
+}
+
+// timingWheel is a hierarchical timing wheel indexing cache items by expiration time.
+// Level 0 holds timers due within the next 64 ticks, one slot per tick; each higher
+// level holds coarser slots that are cascaded into the level below as time advances.
+//
+// Time Complexity:
+//   - schedule: O(1)
+//   - remove: O(1)
+//   - advance: O(ticks elapsed + timers expired), with cascades amortized over the ticks they span
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type timingWheel struct {
+	tick     int64                              // Tick duration in nanoseconds
+	current  int64                              // Last tick that has been processed
+	slots    [wheelLevels][wheelSlots]list.List // Timer slots for every level
+	overflow list.List                          // Timers beyond the range of the top level
+	count    int                                // Number of scheduled timers
+}
+
+// newTimingWheel creates an empty timing wheel.
+//
+// Parameters:
+//   - tick: Duration of a single tick (clamped to minWheelTick)
+//   - now: Time from which the wheel starts advancing
+//
+// Returns:
+//   - *timingWheel: A new timing wheel ready for use
+func newTimingWheel(tick time.Duration, now time.Time) *timingWheel {
+	if tick < minWheelTick {
+		tick = minWheelTick
+	}
+
+	w := &timingWheel{tick: int64(tick)}
+	w.current = now.UnixNano() / w.tick
+	return w
+}
+
+// Len returns the number of scheduled timers.
+func (w *timingWheel) Len() int {
+	return w.count
+}
+
+// schedule places a timer for key expiring at the given time.
+//
+// Parameters:
+//   - t: Existing timer to reuse, or nil to allocate a new one
+//   - key: Cache key of the item
+//   - expireAt: Expiration time of the item
+//
+// Returns:
+//   - *wheelTimer: The scheduled timer
+//
+// The expiration is rounded up to the next tick, so an item is never reported
+// as expired before its expiration time. A timer that is already scheduled is
+// moved to its new slot.
+func (w *timingWheel) schedule(t *wheelTimer, key string, expireAt time.Time) *wheelTimer {
+	if t == nil {
+		t = &wheelTimer{}
+	} else {
+		w.remove(t)
+	}
+
+	nanos := expireAt.UnixNano()
+	t.key = key
+	t.expire = nanos / w.tick
+	if nanos%w.tick != 0 {
+		t.expire++
+	}
+
+	// Timers due in the past fire on the next processed tick
+	if t.expire <= w.current {
+		t.expire = w.current + 1
+	}
+
+	w.place(t)
+	w.count++
+	return t
+}
+
+// remove unschedules a timer. It is safe to call on timers that are not scheduled.
+//
+// Parameters:
+//   - t: Timer to remove
+func (w *timingWheel) remove(t *wheelTimer) {
+	if t == nil || t.bucket == nil {
+		return
+	}
+
+	t.bucket.Remove(t.elem)
+	t.bucket, t.elem = nil, nil
+	w.count--
+}
+
+// advance moves the wheel forward to now and reports every timer that became due.
+//
+// Parameters:
+//   - now: Time to advance to
+//   - expire: Callback invoked with the key of each expired timer
+//
+// Expired timers are unscheduled before the callback runs, so the callback may
+// schedule a new timer for the same key.
+func (w *timingWheel) advance(now time.Time, expire func(key string)) {
+	target := now.UnixNano() / w.tick
+
+	// Nothing to fire: jump straight to the target tick
+	if w.count == 0 {
+		if target > w.current {
+			w.current = target
+		}
+		return
+	}
+
+	for w.current < target {
+		w.current++
+		w.cascade()
+
+		slot := &w.slots[0][w.current&wheelMask]
+		for element := slot.Front(); element != nil; element = slot.Front() {
+			t := slot.Remove(element).(*wheelTimer)
+			t.bucket, t.elem = nil, nil
+			w.count--
+			expire(t.key)
+		}
+	}
+}
+
+// clear unschedules every timer.
+func (w *timingWheel) clear() {
+	for level := range w.slots {
+		for slot := range w.slots[level] {
+			w.slots[level][slot].Init()
+		}
+	}
+	w.overflow.Init()
+	w.count = 0
+}
+
+// cascade redistributes the coarse slots that start at the current tick.
+//
+// When the lower bits of the current tick roll over, the matching slot of each
+// affected higher level is emptied and its timers are placed again relative to
+// the current tick, moving them closer to level 0. Higher levels are cascaded
+// first so their timers can fall through into the levels below.
+func (w *timingWheel) cascade() {
+	if w.current&(wheelSpan-1) == 0 {
+		w.replace(&w.overflow)
+	}
+
+	top := 0
+	for level := 1; level < wheelLevels; level++ {
+		if w.current&(1<<(wheelBits*level)-1) != 0 {
+			break
+		}
+		top = level
+	}
+
+	for level := top; level >= 1; level-- {
+		w.replace(&w.slots[level][(w.current>>(wheelBits*level))&wheelMask])
+	}
+}
+
+// replace empties a slot and places each of its timers again.
+//
+// Parameters:
+//   - bucket: Slot to redistribute
+func (w *timingWheel) replace(bucket *list.List) {
+	timers := make([]*wheelTimer, 0, bucket.Len())
+	for element := bucket.Front(); element != nil; element = element.Next() {
+		timers = append(timers, element.Value.(*wheelTimer))
+	}
+	bucket.Init()
+
+	for _, t := range timers {
+		w.place(t)
+	}
+}
+
+// place inserts a timer into the slot matching its distance from the current tick.
+//
+// Parameters:
+//   - t: Timer to insert
+func (w *timingWheel) place(t *wheelTimer) {
+	if t.expire < w.current {
+		t.expire = w.current
+	}
+
+	delta := t.expire - w.current
+	bucket := &w.overflow
+	for level := 0; level < wheelLevels; level++ {
+		if delta < 1<<(wheelBits*(level+1)) {
+			bucket = &w.slots[level][(t.expire>>(wheelBits*level))&wheelMask]
+			break
+		}
+	}
+
+	t.bucket = bucket
+	t.elem = bucket.PushBack(t)
+}
+
+// wheelTickFor returns the wheel tick matching a sweep interval.
+//
+// Parameters:
+//   - interval: Background sweep interval (0 if no sweeper runs)
+//
+// Returns:
+//   - time.Duration: Tick duration no coarser than the sweep interval
+func wheelTickFor(interval time.Duration) time.Duration {
+	if interval <= 0 || interval > defaultWheelTick {
+		return defaultWheelTick
+	}
+	return interval
+}
@@ -0,0 +1,141 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTimingWheel(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tick := time.Millisecond
+
+	// 测试不同层级的定时器都能在正确的时间点到期
+	t.Run("expire across levels", func(t *testing.T) {
+		wheel := newTimingWheel(tick, start)
+		delays := []time.Duration{
+			5 * tick,
+			100 * tick,      // 第1层
+			5000 * tick,     // 第2层
+			300000 * tick,   // 第3层
+			20000000 * tick, // 溢出列表
+		}
+		for i, delay := range delays {
+			wheel.schedule(nil, fmt.Sprintf("key%d", i), start.Add(delay))
+		}
+
+		for i, delay := range delays {
+			var expired []string
+			collect := func(key string) { expired = append(expired, key) }
+
+			// 到期前不应触发
+			wheel.advance(start.Add(delay-tick), collect)
+			if len(expired) != 0 {
+				t.Fatalf("delay %v: expired early: %v", delay, expired)
+			}
+
+			wheel.advance(start.Add(delay), collect)
+			if len(expired) != 1 || expired[0] != fmt.Sprintf("key%d", i) {
+				t.Fatalf("delay %v: expired = %v, want [key%d]", delay, expired, i)
+			}
+		}
+
+		if wheel.Len() != 0 {
+			t.Errorf("Len = %d, want 0", wheel.Len())
+		}
+	})
+
+	// 测试移除与重新调度
+	t.Run("remove and reschedule", func(t *testing.T) {
+		wheel := newTimingWheel(tick, start)
+		removed := wheel.schedule(nil, "removed", start.Add(10*tick))
+		moved := wheel.schedule(nil, "moved", start.Add(10*tick))
+
+		wheel.remove(removed)
+		wheel.remove(removed) // 重复移除不应出错
+		wheel.schedule(moved, "moved", start.Add(500*tick))
+
+		var expired []string
+		wheel.advance(start.Add(100*tick), func(key string) { expired = append(expired, key) })
+		if len(expired) != 0 {
+			t.Errorf("expired = %v, want none", expired)
+		}
+
+		wheel.advance(start.Add(500*tick), func(key string) { expired = append(expired, key) })
+		if len(expired) != 1 || expired[0] != "moved" {
+			t.Errorf("expired = %v, want [moved]", expired)
+		}
+	})
+
+	// 测试已过期的时间在下一个tick触发
+	t.Run("past expiration", func(t *testing.T) {
+		wheel := newTimingWheel(tick, start)
+		wheel.schedule(nil, "past", start.Add(-time.Hour))
+
+		var expired []string
+		wheel.advance(start.Add(tick), func(key string) { expired = append(expired, key) })
+		if len(expired) != 1 {
+			t.Errorf("expired = %v, want [past]", expired)
+		}
+	})
+
+	// 测试清空
+	t.Run("clear", func(t *testing.T) {
+		wheel := newTimingWheel(tick, start)
+		for i := 0; i < 100; i++ {
+			wheel.schedule(nil, fmt.Sprintf("key%d", i), start.Add(time.Duration(i)*time.Second))
+		}
+		wheel.clear()
+
+		if wheel.Len() != 0 {
+			t.Errorf("Len after clear = %d, want 0", wheel.Len())
+		}
+		wheel.advance(start.Add(time.Minute), func(key string) {
+			t.Errorf("unexpected expiration of %s after clear", key)
+		})
+	})
+}
+
+func TestCacheExpirationIndex(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithCleanupInterval(5*time.Millisecond))
+	defer cache.Close()
+
+	cache.Set("deleted", toBytes("value"), 10*time.Millisecond)
+	cache.Set("updated", toBytes("value"), 10*time.Millisecond)
+	cache.Set("expiring", toBytes("value"), 10*time.Millisecond)
+
+	// 删除和重新设置都应更新过期索引
+	cache.Delete("deleted")
+	cache.Set("updated", toBytes("value"), 0)
+
+	time.Sleep(50 * time.Millisecond)
+
+	stats := cache.Stats()
+	if stats.Expirations != 1 {
+		t.Errorf("Expirations = %d, want 1", stats.Expirations)
+	}
+	if _, err := cache.Get("updated"); err != nil {
+		t.Errorf("updated key should not expire: %v", err)
+	}
+
+	// 清空后索引中不应残留定时器
+	cache.Set("cleared", toBytes("value"), time.Hour)
+	cache.Clear()
+	for _, shard := range cache.shards {
+		if shard.expiry.Len() != 0 {
+			t.Errorf("expiration index not empty after Clear: %d", shard.expiry.Len())
+		}
+	}
+}
+
+func BenchmarkTimingWheelSchedule(b *testing.B) {
+	start := time.Now()
+	wheel := newTimingWheel(time.Millisecond, start)
+	timers := make([]*wheelTimer, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		slot := i % len(timers)
+		timers[slot] = wheel.schedule(timers[slot], "key", start.Add(time.Duration(i%100000)*time.Millisecond))
+	}
+}
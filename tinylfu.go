@@ -0,0 +1,461 @@
+package tscache
+
+import (
+	"container/list"
+)
+
+// W-TinyLFU parameters
+const (
+	tinyLFUWindowPercent    = 1  // Share of the total weight kept in the admission window
+	tinyLFUProtectedPercent = 80 // Share of the main region reserved for the protected segment
+
+	sketchDepth      = 4  // Number of hash rows in the count-min sketch
+	sketchMinWidth   = 64 // Minimum number of counters per row
+	sketchMaxCount   = 15 // Counters saturate at this value (4-bit counters)
+	sketchSampleRate = 10 // Counters are halved after width*sketchSampleRate increments
+)
+
+// Segments of the W-TinyLFU policy
+const (
+	tinyLFUWindow    = iota // Admission window, an LRU holding the most recent items
+	tinyLFUProbation        // Main region items that have not been accessed since admission
+	tinyLFUProtected        // Main region items accessed at least once after admission
+)
+
+// countMinSketch estimates access frequencies in constant memory. Every key maps to
+// one counter per row, and its frequency is the minimum of those counters, which
+// overestimates only on hash collisions. Counters are periodically halved so that
+// the estimate follows changes in popularity instead of accumulating forever.
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type countMinSketch struct {
+	rows      [sketchDepth][]uint8 // Counter rows, each of the same power of two width
+	mask      uint32               // Width - 1, used to map hashes to counters
+	additions int                  // Increments since the last halving
+	sample    int                  // Number of increments after which counters are halved
+}
+
+// newCountMinSketch creates a sketch sized for roughly width distinct keys.
+//
+// Parameters:
+//   - width: Expected number of distinct keys (rounded up to a power of 2)
+//
+// Returns:
+//   - *countMinSketch: A new sketch with all counters at zero
+func newCountMinSketch(width int) *countMinSketch {
+	width = roundToPowerOfTwo(max(width, sketchMinWidth))
+
+	sketch := &countMinSketch{
+		mask:   uint32(width - 1),
+		sample: width * sketchSampleRate,
+	}
+	for i := range sketch.rows {
+		sketch.rows[i] = make([]uint8, width)
+	}
+	return sketch
+}
+
+// width returns the number of counters per row.
+func (cms *countMinSketch) width() int {
+	return len(cms.rows[0])
+}
+
+// increment records one access to key, halving every counter once enough
+// accesses have been recorded.
+//
+// Parameters:
+//   - key: Accessed cache key
+func (cms *countMinSketch) increment(key string) {
+	h1, h2 := sketchHashes(key)
+
+	added := false
+	for i := range cms.rows {
+		index := (h1 + uint32(i)*h2) & cms.mask
+		if cms.rows[i][index] < sketchMaxCount {
+			cms.rows[i][index]++
+			added = true
+		}
+	}
+
+	if added {
+		cms.additions++
+		if cms.additions >= cms.sample {
+			cms.halve()
+		}
+	}
+}
+
+// estimate returns the estimated access frequency of key.
+//
+// Parameters:
+//   - key: Cache key to look up
+//
+// Returns:
+//   - int: Estimated number of recent accesses, at most sketchMaxCount
+func (cms *countMinSketch) estimate(key string) int {
+	h1, h2 := sketchHashes(key)
+
+	frequency := sketchMaxCount
+	for i := range cms.rows {
+		index := (h1 + uint32(i)*h2) & cms.mask
+		frequency = min(frequency, int(cms.rows[i][index]))
+	}
+	return frequency
+}
+
+// grow widens every row to hold roughly width distinct keys, keeping the
+// recorded frequencies.
+//
+// Parameters:
+//   - width: Expected number of distinct keys (rounded up to a power of 2)
+//
+// A key's counter in the wider row is at an index whose low bits equal its index
+// in the old row, so every new counter starts from the old counter it splits from.
+func (cms *countMinSketch) grow(width int) {
+	width = roundToPowerOfTwo(width)
+	if width <= cms.width() {
+		return
+	}
+
+	for i, row := range cms.rows {
+		wider := make([]uint8, width)
+		for j := range wider {
+			wider[j] = row[uint32(j)&cms.mask]
+		}
+		cms.rows[i] = wider
+	}
+	cms.mask = uint32(width - 1)
+	cms.sample = width * sketchSampleRate
+}
+
+// halve divides every counter by two, aging the recorded frequencies.
+func (cms *countMinSketch) halve() {
+	for i := range cms.rows {
+		for j := range cms.rows[i] {
+			cms.rows[i][j] >>= 1
+		}
+	}
+	cms.additions /= 2
+}
+
+// sketchHashes derives the two hashes used to select a key's counters
+// (Kirsch-Mitzenmacher double hashing).
+//
+// Parameters:
+//   - key: Cache key to hash
+//
+// Returns:
+//   - uint32: Base hash
+//   - uint32: Step between rows, always odd
+//
+// Shards are selected by the low bits of fnv1a, so all keys of a shard share
+// them. The hash is therefore passed through the murmur3 finalizer first, which
+// makes every bit of the result depend on every bit of the input; otherwise the
+// counters of each row reachable from one shard would be a small fraction of its width.
+func sketchHashes(key string) (uint32, uint32) {
+	h1 := fnv1a(key)
+	h1 ^= h1 >> 16
+	h1 *= 0x85EBCA6B
+	h1 ^= h1 >> 13
+	h1 *= 0xC2B2AE35
+	h1 ^= h1 >> 16
+
+	h2 := (h1*0x9E3779B1)>>7 | 1
+	return h1, h2
+}
+
+// TinyLFUNode represents an item tracked by the W-TinyLFU policy.
+type TinyLFUNode struct {
+	Key     string     // Cache key for this node
+	Item    *CacheItem // Reference to the actual cache item
+	Weight  int        // Memory weight of the item (at least 1)
+	Segment int        // Segment currently holding the node
+}
+
+// TinyLFUList implements the W-TinyLFU eviction policy. New items enter a small
+// LRU admission window. Items leaving the window compete with the eviction
+// candidate of the main region, and only the one that a count-min sketch
+// estimates to be accessed more often is kept. The main region is a segmented
+// LRU: items are admitted into probation and promoted to the protected segment
+// when accessed again, so a burst of one-time accesses cannot flush items that
+// are used repeatedly.
+//
+// The shard limits memory rather than item count, so segment sizes are shares of
+// the capacity set with SetCapacity, normally the shard memory limit. Once the
+// main region is full, items leaving the window wait there until the next
+// eviction decides whether they are admitted. Without a capacity, segment sizes
+// are shares of the total weight currently tracked and the main region is never
+// considered full, so items leave the window without an admission check.
+//
+// Time Complexity:
+//   - Add: O(1) amortized
+//   - Remove: O(1) with hash map lookup
+//   - Update: O(1) amortized
+//   - RemoveLeast: O(1)
+//
+// Note: This implementation is NOT thread-safe. Thread safety is handled at the shard level.
+type TinyLFUList struct {
+	segments [3]*list.List            // Window, probation and protected LRU lists (front = most recent)
+	weights  [3]int                   // Total weight held by each segment
+	nodeMap  map[string]*list.Element // Hash map for O(1) key-to-node lookup
+	sketch   *countMinSketch          // Access frequency estimator
+	capacity int                      // Total weight the list is sized for, 0 if unknown
+}
+
+// NewTinyLFUList creates a new W-TinyLFU eviction list.
+//
+// Returns:
+//   - *TinyLFUList: A new W-TinyLFU list ready for use
+//
+// The frequency sketch starts small and grows with the number of tracked items,
+// keeping the frequencies recorded so far.
+func NewTinyLFUList() *TinyLFUList {
+	tlfu := &TinyLFUList{}
+	tlfu.Clear()
+	return tlfu
+}
+
+// SetCapacity sets the total weight the W-TinyLFU list is sized for.
+//
+// Parameters:
+//   - capacity: Capacity in bytes, normally the shard memory limit
+func (tlfu *TinyLFUList) SetCapacity(capacity int) {
+	tlfu.capacity = max(capacity, 0)
+}
+
+// Add inserts a new item into the admission window, or refreshes an existing item.
+//
+// Parameters:
+//   - key: Cache key identifier
+//   - item: Cache item to add or update
+//
+// Every call counts as an access in the frequency sketch. Items pushed out of
+// the window by the new item move to the probation segment of the main region
+// while it has room. Once it is full they stay in the window as candidates for
+// admission, which RemoveLeast decides.
+func (tlfu *TinyLFUList) Add(key string, item *CacheItem) {
+	if element, exists := tlfu.nodeMap[key]; exists {
+		node := element.Value.(*TinyLFUNode)
+		tlfu.weights[node.Segment] += tinyLFUWeight(item) - node.Weight
+		node.Weight = tinyLFUWeight(item)
+		tlfu.Update(key, item)
+		return
+	}
+
+	if len(tlfu.nodeMap) >= tlfu.sketch.width() {
+		tlfu.sketch.grow(2 * len(tlfu.nodeMap))
+	}
+	tlfu.sketch.increment(key)
+
+	node := &TinyLFUNode{
+		Key:     key,
+		Item:    item,
+		Weight:  tinyLFUWeight(item),
+		Segment: tinyLFUWindow,
+	}
+	tlfu.nodeMap[key] = tlfu.segments[tinyLFUWindow].PushFront(node)
+	tlfu.weights[tinyLFUWindow] += node.Weight
+
+	// Move items exceeding the window share into the main region while it has room
+	windowMax := tlfu.windowMax()
+	for {
+		tail := tlfu.segments[tinyLFUWindow].Back()
+		if tail == nil {
+			break
+		}
+		weight := tail.Value.(*TinyLFUNode).Weight
+		if tlfu.weights[tinyLFUWindow]-weight < windowMax || !tlfu.mainHasRoom(weight) {
+			break
+		}
+		tlfu.move(tail, tinyLFUProbation)
+	}
+}
+
+// Remove deletes an item from the W-TinyLFU list.
+//
+// Parameters:
+//   - key: Cache key to remove
+//
+// The key's frequency history is kept in the sketch, so a key that returns
+// soon after being removed is still recognized as popular.
+func (tlfu *TinyLFUList) Remove(key string) {
+	if element, exists := tlfu.nodeMap[key]; exists {
+		node := element.Value.(*TinyLFUNode)
+		tlfu.segments[node.Segment].Remove(element)
+		tlfu.weights[node.Segment] -= node.Weight
+		delete(tlfu.nodeMap, key)
+	}
+}
+
+// Update records an access to an item and adjusts its position.
+//
+// Parameters:
+//   - key: Cache key to update
+//   - item: Updated cache item
+//
+// Items in the window or the protected segment move to the front of their
+// segment. Items in probation are promoted to the protected segment, demoting
+// the least recently used protected items back to probation if it grows past
+// its share of the main region.
+func (tlfu *TinyLFUList) Update(key string, item *CacheItem) {
+	element, exists := tlfu.nodeMap[key]
+	if !exists {
+		return
+	}
+
+	tlfu.sketch.increment(key)
+
+	node := element.Value.(*TinyLFUNode)
+	node.Item = item
+
+	if node.Segment != tinyLFUProbation {
+		tlfu.segments[node.Segment].MoveToFront(element)
+		return
+	}
+
+	tlfu.move(element, tinyLFUProtected)
+
+	protectedMax := tlfu.mainMax() * tinyLFUProtectedPercent / 100
+	for tlfu.weights[tinyLFUProtected] > protectedMax {
+		tail := tlfu.segments[tinyLFUProtected].Back()
+		if tail == tlfu.nodeMap[key] {
+			break
+		}
+		tlfu.move(tail, tinyLFUProbation)
+	}
+}
+
+// RemoveLeast evicts the item with the lowest value according to W-TinyLFU.
+//
+// Returns:
+//   - string: Key of the evicted item, empty string if list is empty
+//
+// When the window exceeds its share because the main region is full, its least
+// recent item (the candidate) competes with the least recent item of the main
+// region (the victim): the candidate is admitted into probation only if its
+// estimated frequency is higher than the victim's, otherwise it is evicted
+// itself. Otherwise the victim is evicted.
+func (tlfu *TinyLFUList) RemoveLeast() string {
+	candidate := tlfu.segments[tinyLFUWindow].Back()
+	victim := tlfu.mainVictim()
+
+	switch {
+	case candidate == nil && victim == nil:
+		return ""
+	case candidate == nil:
+		return tlfu.evict(victim)
+	case victim == nil:
+		return tlfu.evict(candidate)
+	case tlfu.weights[tinyLFUWindow] <= tlfu.windowMax():
+		return tlfu.evict(victim)
+	}
+
+	candidateKey := candidate.Value.(*TinyLFUNode).Key
+	victimKey := victim.Value.(*TinyLFUNode).Key
+	if tlfu.sketch.estimate(candidateKey) > tlfu.sketch.estimate(victimKey) {
+		tlfu.move(candidate, tinyLFUProbation)
+		return tlfu.evict(victim)
+	}
+	return tlfu.evict(candidate)
+}
+
+// Clear removes all items from the W-TinyLFU list and resets its frequency history.
+func (tlfu *TinyLFUList) Clear() {
+	for i := range tlfu.segments {
+		tlfu.segments[i] = list.New()
+		tlfu.weights[i] = 0
+	}
+	tlfu.nodeMap = make(map[string]*list.Element)
+	tlfu.sketch = newCountMinSketch(sketchMinWidth)
+}
+
+// mainVictim returns the eviction candidate of the main region.
+//
+// Returns:
+//   - *list.Element: Least recent probation item, or the least recent protected
+//     item if probation is empty; nil if the main region is empty
+func (tlfu *TinyLFUList) mainVictim() *list.Element {
+	if victim := tlfu.segments[tinyLFUProbation].Back(); victim != nil {
+		return victim
+	}
+	return tlfu.segments[tinyLFUProtected].Back()
+}
+
+// move transfers a node to the front of another segment.
+//
+// Parameters:
+//   - element: List element of the node
+//   - segment: Destination segment
+func (tlfu *TinyLFUList) move(element *list.Element, segment int) {
+	node := element.Value.(*TinyLFUNode)
+	tlfu.segments[node.Segment].Remove(element)
+	tlfu.weights[node.Segment] -= node.Weight
+
+	node.Segment = segment
+	tlfu.nodeMap[node.Key] = tlfu.segments[segment].PushFront(node)
+	tlfu.weights[segment] += node.Weight
+}
+
+// evict removes a node from the list and returns its key.
+//
+// Parameters:
+//   - element: List element of the node to evict
+//
+// Returns:
+//   - string: Key of the evicted node
+func (tlfu *TinyLFUList) evict(element *list.Element) string {
+	node := element.Value.(*TinyLFUNode)
+	tlfu.segments[node.Segment].Remove(element)
+	tlfu.weights[node.Segment] -= node.Weight
+	delete(tlfu.nodeMap, node.Key)
+	return node.Key
+}
+
+// mainHasRoom reports whether the main region can take an item without exceeding
+// its share of the capacity. Without a capacity it always has room.
+//
+// Parameters:
+//   - weight: Weight of the item leaving the window
+//
+// Returns:
+//   - bool: true if the item can move into probation without an admission check
+func (tlfu *TinyLFUList) mainHasRoom(weight int) bool {
+	if tlfu.capacity == 0 {
+		return true
+	}
+	return tlfu.weights[tinyLFUProbation]+tlfu.weights[tinyLFUProtected]+weight <= tlfu.mainMax()
+}
+
+// mainMax returns the weight share of the main region.
+func (tlfu *TinyLFUList) mainMax() int {
+	if tlfu.capacity == 0 {
+		return tlfu.totalWeight() - tlfu.windowMax()
+	}
+	return max(tlfu.capacity-tlfu.windowMax(), 0)
+}
+
+// totalWeight returns the weight held by all segments.
+func (tlfu *TinyLFUList) totalWeight() int {
+	return tlfu.weights[tinyLFUWindow] + tlfu.weights[tinyLFUProbation] + tlfu.weights[tinyLFUProtected]
+}
+
+// windowMax returns the weight share of the admission window, at least 1 so that
+// the most recent item always passes through admission.
+func (tlfu *TinyLFUList) windowMax() int {
+	total := tlfu.capacity
+	if total == 0 {
+		total = tlfu.totalWeight()
+	}
+	return max(total*tinyLFUWindowPercent/100, 1)
+}
+
+// tinyLFUWeight returns the weight of an item, counting empty items as 1.
+//
+// Parameters:
+//   - item: Cache item to weigh
+//
+// Returns:
+//   - int: Item weight
+func tinyLFUWeight(item *CacheItem) int {
+	return max(item.Size, 1)
+}
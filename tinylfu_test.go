@@ -0,0 +1,230 @@
+package tscache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	sketch := newCountMinSketch(1024)
+
+	for i := 0; i < 10; i++ {
+		sketch.increment("hot")
+	}
+	sketch.increment("cold")
+
+	if freq := sketch.estimate("hot"); freq != 10 {
+		t.Errorf("estimate(hot) = %d, want 10", freq)
+	}
+	if freq := sketch.estimate("cold"); freq != 1 {
+		t.Errorf("estimate(cold) = %d, want 1", freq)
+	}
+	if freq := sketch.estimate("missing"); freq != 0 {
+		t.Errorf("estimate(missing) = %d, want 0", freq)
+	}
+
+	// 计数器上限为15
+	for i := 0; i < 20; i++ {
+		sketch.increment("hot")
+	}
+	if freq := sketch.estimate("hot"); freq != sketchMaxCount {
+		t.Errorf("estimate(hot) = %d, want %d", freq, sketchMaxCount)
+	}
+
+	// 达到采样数后所有计数器减半
+	sketch.halve()
+	if freq := sketch.estimate("hot"); freq != sketchMaxCount/2 {
+		t.Errorf("estimate(hot) after halving = %d, want %d", freq, sketchMaxCount/2)
+	}
+	if freq := sketch.estimate("cold"); freq != 0 {
+		t.Errorf("estimate(cold) after halving = %d, want 0", freq)
+	}
+}
+
+func TestCountMinSketchAging(t *testing.T) {
+	sketch := newCountMinSketch(1024)
+	for i := 0; i < 10; i++ {
+		sketch.increment("old")
+	}
+
+	// 大量新访问会触发周期性减半，使旧的热点逐渐冷却
+	for i := 0; i < sketch.sample; i++ {
+		sketch.increment(fmt.Sprintf("key%d", i))
+	}
+	if freq := sketch.estimate("old"); freq >= 10 {
+		t.Errorf("estimate(old) = %d, want it to decay below 10", freq)
+	}
+}
+
+func TestCountMinSketchShardSpread(t *testing.T) {
+	// 模拟64个分片中的一个：同一分片的键fnv1a低位相同
+	const shards, keys = 64, 1024
+	sketch := newCountMinSketch(4096)
+	for i, added := 0, 0; added < keys; i++ {
+		key := fmt.Sprintf("key%d", i)
+		if fnv1a(key)%shards == 0 {
+			sketch.increment(key)
+			added++
+		}
+	}
+
+	// 每行使用的计数器数量都应接近随机分布的期望值（约906个），而不只是width/shards个
+	for row := range sketch.rows {
+		used := 0
+		for _, counter := range sketch.rows[row] {
+			if counter > 0 {
+				used++
+			}
+		}
+		if used < 800 {
+			t.Errorf("row %d uses %d of %d counters for %d keys of one shard", row, used, sketch.width(), keys)
+		}
+	}
+}
+
+func TestCountMinSketchGrow(t *testing.T) {
+	sketch := newCountMinSketch(64)
+	for i := 0; i < 7; i++ {
+		sketch.increment("key")
+	}
+
+	// 扩容后保留已记录的频率
+	sketch.grow(4096)
+	if width := sketch.width(); width != 4096 {
+		t.Errorf("width = %d, want 4096", width)
+	}
+	if freq := sketch.estimate("key"); freq < 7 {
+		t.Errorf("estimate(key) after grow = %d, want at least 7", freq)
+	}
+}
+
+func TestTinyLFUList(t *testing.T) {
+	tlfu := NewTinyLFUList()
+
+	for i := 0; i < 5; i++ {
+		key := fmt.Sprintf("key%d", i)
+		tlfu.Add(key, &CacheItem{Key: key, Size: 10})
+	}
+
+	tlfu.Remove("key2")
+	tlfu.Remove("nonexistent") // 不应该出错
+	tlfu.Update("nonexistent", &CacheItem{Size: 10})
+
+	seen := make(map[string]bool)
+	for key := tlfu.RemoveLeast(); key != ""; key = tlfu.RemoveLeast() {
+		if seen[key] {
+			t.Errorf("key %s evicted twice", key)
+		}
+		seen[key] = true
+	}
+	if len(seen) != 4 || seen["key2"] {
+		t.Errorf("evicted %v, want the 4 remaining keys", seen)
+	}
+	if total := tlfu.totalWeight(); total != 0 {
+		t.Errorf("totalWeight = %d after evicting everything, want 0", total)
+	}
+}
+
+// newTestTinyLFUList 创建容量为capacity的W-TinyLFU列表
+func newTestTinyLFUList(capacity int) *TinyLFUList {
+	tlfu := NewTinyLFUList()
+	tlfu.SetCapacity(capacity)
+	return tlfu
+}
+
+func TestTinyLFUAdmission(t *testing.T) {
+	tlfu := newTestTinyLFUList(100)
+
+	// 填满窗口和主区域，并多次访问这些数据
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("hot%d", i)
+		tlfu.Add(key, &CacheItem{Key: key, Size: 1})
+	}
+	if main := tlfu.weights[tinyLFUProbation] + tlfu.weights[tinyLFUProtected]; main != 99 {
+		t.Fatalf("main region weight = %d, want 99", main)
+	}
+
+	// 主区域已满时新数据留在窗口中等待准入
+	tlfu.Add("cold", &CacheItem{Key: "cold", Size: 1})
+	if node := tlfu.nodeMap["hot99"].Value.(*TinyLFUNode); node.Segment != tinyLFUWindow {
+		t.Errorf("hot99 moved to segment %d while the main region is full", node.Segment)
+	}
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("hot%d", i)
+			tlfu.Update(key, &CacheItem{Key: key, Size: 1})
+		}
+	}
+
+	// 访问频率低于主区域淘汰候选的新数据被拒绝
+	if removed := tlfu.RemoveLeast(); removed != "cold" {
+		t.Errorf("RemoveLeast() = %q, want the cold key to be rejected", removed)
+	}
+
+	// 访问频率更高的候选数据被准入，淘汰主区域的数据
+	for i := 0; i < 5; i++ {
+		tlfu.Update("hot99", &CacheItem{Key: "hot99", Size: 1})
+	}
+	tlfu.Add("new", &CacheItem{Key: "new", Size: 1})
+	tlfu.Update("new", &CacheItem{Key: "new", Size: 1})
+	removed := tlfu.RemoveLeast()
+	if removed == "hot99" || removed == "new" {
+		t.Errorf("RemoveLeast() = %q, want a main region item", removed)
+	}
+	if node := tlfu.nodeMap["hot99"].Value.(*TinyLFUNode); node.Segment != tinyLFUProbation {
+		t.Errorf("hot99 is in segment %d, want probation after admission", node.Segment)
+	}
+}
+
+func TestTinyLFUScanResistance(t *testing.T) {
+	keys := scanWorkload(50, 10000)
+
+	countHot := func(resident map[string]bool) int {
+		survivors := 0
+		for i := 0; i < 50; i++ {
+			if resident[fmt.Sprintf("hot%d", i)] {
+				survivors++
+			}
+		}
+		return survivors
+	}
+
+	tinyLFU := countHot(simulateEviction(newTestTinyLFUList(100), 100, keys).resident)
+	lru := countHot(simulateEviction(NewLRUList(), 100, keys).resident)
+
+	if tinyLFU < 45 {
+		t.Errorf("only %d of 50 hot keys survived the scan with TinyLFU", tinyLFU)
+	}
+	if tinyLFU <= lru {
+		t.Errorf("TinyLFU kept %d hot keys, LRU kept %d; TinyLFU should keep more", tinyLFU, lru)
+	}
+}
+
+func TestTinyLFUCacheIntegration(t *testing.T) {
+	cache := NewCache(WithMaxSize(64*1024), WithEvictionPolicy(EvictionTinyLFU))
+	if policy := cache.Stats().EvictionPolicy; policy != EvictionTinyLFU {
+		t.Fatalf("EvictionPolicy = %s, want %s", policy, EvictionTinyLFU)
+	}
+
+	value := make([]byte, 100)
+	for _, key := range scanWorkload(100, 20000) {
+		if _, err := cache.Get(key); err != nil {
+			cache.Set(key, value, 0)
+		}
+	}
+
+	hits := 0
+	for i := 0; i < 100; i++ {
+		if _, err := cache.Get(fmt.Sprintf("hot%d", i)); err == nil {
+			hits++
+		}
+	}
+	if hits < 80 {
+		t.Errorf("only %d of 100 hot keys survived the scan", hits)
+	}
+
+	stats := cache.Stats()
+	if stats.CurrentSize > stats.MaxSize {
+		t.Errorf("CurrentSize %d exceeds MaxSize %d", stats.CurrentSize, stats.MaxSize)
+	}
+}
@@ -0,0 +1,134 @@
+package tscache
+
+import (
+	"time"
+)
+
+// NoExpiration is returned by TTL for items that never expire.
+const NoExpiration time.Duration = -1
+
+// TTL returns the remaining time to live of an item.
+//
+// Parameters:
+//   - key: The cache key to inspect
+//
+// Returns:
+//   - time.Duration: Remaining time to live, or NoExpiration if the item never expires
+//   - error: ErrKeyNotFound if the key doesn't exist or has expired
+//
+// For items with an idle timeout the result is the time left until the earlier of the
+// absolute expiration and the end of the current idle period. Inspecting the TTL does
+// not count as access and updates neither hit statistics nor eviction order.
+func (c *Cache) TTL(key string) (time.Duration, error) {
+	return c.getShard(key).ttl(key)
+}
+
+// Touch resets the expiration of an item to ttl from now without rewriting its value.
+//
+// Parameters:
+//   - key: The cache key to update
+//   - ttl: New time to live (0 removes the expiration)
+//
+// Returns:
+//   - error: ErrKeyNotFound if the key doesn't exist or has expired
+//
+// Only the absolute expiration changes; an idle timeout set with SetWithOptions stays
+// in effect, so the item still expires after that period without a successful Get.
+func (c *Cache) Touch(key string, ttl time.Duration) error {
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl)
+	}
+
+	return c.getShard(key).expireAt(key, expireAt)
+}
+
+// ExpireAt sets the absolute expiration time of an item without rewriting its value.
+//
+// Parameters:
+//   - key: The cache key to update
+//   - t: New expiration time (zero value removes the expiration)
+//
+// Returns:
+//   - error: ErrKeyNotFound if the key doesn't exist or has expired
+//
+// A time that already lies in the past removes the item immediately; it is reported
+// to the removal callback with the Expired reason.
+func (c *Cache) ExpireAt(key string, t time.Time) error {
+	return c.getShard(key).expireAt(key, t)
+}
+
+// Persist removes the absolute expiration of an item.
+//
+// Parameters:
+//   - key: The cache key to update
+//
+// Returns:
+//   - error: ErrKeyNotFound if the key doesn't exist or has expired
+//
+// An idle timeout is kept: an idle item still expires after that period without a
+// successful Get. Other items are afterwards only removed by eviction or deletion.
+func (c *Cache) Persist(key string) error {
+	return c.getShard(key).expireAt(key, time.Time{})
+}
+
+// ttl returns the remaining time to live of an item in this shard.
+//
+// Parameters:
+//   - key: The cache key to inspect
+//
+// Returns:
+//   - time.Duration: Remaining time to live, or NoExpiration
+//   - error: ErrKeyNotFound if the key doesn't exist or has expired
+func (s *CacheShard) ttl(key string) (time.Duration, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	item, exists := s.lookupLocked(key, now)
+	if !exists {
+		return 0, ErrKeyNotFound
+	}
+	deadline := item.deadline()
+	if deadline.IsZero() {
+		return NoExpiration, nil
+	}
+
+	return deadline.Sub(now), nil
+}
+
+// expireAt changes the expiration time of an item in place and reschedules it.
+//
+// Parameters:
+//   - key: The cache key to update
+//   - t: New expiration time (zero value removes the expiration)
+//
+// Returns:
+//   - error: ErrKeyNotFound if the key doesn't exist or has expired
+//
+// The value is left untouched, so it is neither recompressed nor given a new version.
+func (s *CacheShard) expireAt(key string, t time.Time) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.unlock()
+
+	item, exists := s.lookupLocked(key, now)
+	if !exists {
+		return ErrKeyNotFound
+	}
+
+	if !t.IsZero() && !t.After(now) {
+		s.deleteLocked(key, item, Expired)
+
+		s.stats.mu.Lock()
+		s.stats.Expirations++
+		s.stats.mu.Unlock()
+		return nil
+	}
+
+	item.ExpireAt = t
+	s.scheduleExpiry(item)
+	return nil
+}
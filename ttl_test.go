@@ -0,0 +1,153 @@
+package tscache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCacheTTL(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.Set("persistent", toBytes("value"), 0)
+	cache.Set("expiring", toBytes("value"), time.Hour)
+
+	if ttl, err := cache.TTL("persistent"); err != nil || ttl != NoExpiration {
+		t.Errorf("TTL(persistent) = %v, %v; want NoExpiration", ttl, err)
+	}
+	if ttl, err := cache.TTL("expiring"); err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Errorf("TTL(expiring) = %v, %v; want about 1h", ttl, err)
+	}
+	if _, err := cache.TTL("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TTL(missing) error = %v, want ErrKeyNotFound", err)
+	}
+
+	// 查询TTL不影响命中统计
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Misses != 0 {
+		t.Errorf("Hits = %d, Misses = %d after TTL, want 0", stats.Hits, stats.Misses)
+	}
+}
+
+func TestCacheTouch(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.Set("key", toBytes("value"), 30*time.Millisecond)
+	_, version, _ := cache.GetWithVersion("key")
+
+	if err := cache.Touch("key", time.Hour); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	value, newVersion, err := cache.GetWithVersion("key")
+	if err != nil || string(value) != "value" {
+		t.Fatalf("Get after Touch = %q, %v", value, err)
+	}
+	// 值未被重写，版本号不变
+	if newVersion != version {
+		t.Errorf("version changed from %d to %d", version, newVersion)
+	}
+
+	if err := cache.Touch("key", 0); err != nil {
+		t.Fatalf("Touch(0) failed: %v", err)
+	}
+	if ttl, _ := cache.TTL("key"); ttl != NoExpiration {
+		t.Errorf("TTL after Touch(0) = %v, want NoExpiration", ttl)
+	}
+
+	if err := cache.Touch("missing", time.Hour); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Touch(missing) error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCacheExpireAtAndPersist(t *testing.T) {
+	recorder := newRemovalRecorder()
+	cache := NewCache(WithMaxSize(1024*1024), WithOnEvict(recorder.record))
+
+	cache.Set("key", toBytes("value"), 0)
+	deadline := time.Now().Add(30 * time.Millisecond)
+	if err := cache.ExpireAt("key", deadline); err != nil {
+		t.Fatalf("ExpireAt failed: %v", err)
+	}
+	if ttl, _ := cache.TTL("key"); ttl <= 0 || ttl > 30*time.Millisecond {
+		t.Errorf("TTL after ExpireAt = %v", ttl)
+	}
+
+	if err := cache.Persist("key"); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, err := cache.Get("key"); err != nil {
+		t.Errorf("persisted key should not expire: %v", err)
+	}
+
+	// 过去的时间会立即删除数据
+	if err := cache.ExpireAt("key", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("ExpireAt in the past failed: %v", err)
+	}
+	if _, err := cache.Get("key"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get after past ExpireAt error = %v, want ErrKeyNotFound", err)
+	}
+	if reason, _ := recorder.reason("key"); reason != Expired {
+		t.Errorf("removal reason = %v, want Expired", reason)
+	}
+	if stats := cache.Stats(); stats.Expirations != 1 || stats.CurrentCount != 0 {
+		t.Errorf("Expirations = %d, CurrentCount = %d", stats.Expirations, stats.CurrentCount)
+	}
+
+	if err := cache.Persist("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Persist(missing) error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCacheTouchReschedulesJanitor(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024*1024), WithCleanupInterval(10*time.Millisecond))
+	defer cache.Close()
+
+	cache.Set("extended", toBytes("value"), 20*time.Millisecond)
+	cache.Set("shortened", toBytes("value"), time.Hour)
+	cache.Touch("extended", time.Hour)
+	cache.ExpireAt("shortened", time.Now().Add(20*time.Millisecond))
+
+	time.Sleep(100 * time.Millisecond)
+
+	// 后台清理依据新的过期时间
+	stats := cache.Stats()
+	if stats.CurrentCount != 1 || stats.Expirations != 1 {
+		t.Errorf("CurrentCount = %d, Expirations = %d; want 1, 1", stats.CurrentCount, stats.Expirations)
+	}
+	if _, err := cache.TTL("extended"); err != nil {
+		t.Errorf("extended key should remain: %v", err)
+	}
+}
+
+func TestCachePersistKeepsIdleTimeout(t *testing.T) {
+	cache := NewCache(WithMaxSize(1024 * 1024))
+
+	cache.SetWithOptions("session", toBytes("data"), SetOptions{
+		TTL:         time.Hour,
+		IdleTimeout: 30 * time.Millisecond,
+	})
+	if err := cache.Persist("session"); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+
+	// 移除绝对过期时间后, 空闲超时仍然生效
+	if ttl, _ := cache.TTL("session"); ttl <= 0 || ttl > 30*time.Millisecond {
+		t.Errorf("TTL after Persist = %v, want the idle period", ttl)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, err := cache.Get("session"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("idle key after Persist error = %v, want ErrKeyNotFound", err)
+	}
+
+	// Touch 同样只修改绝对过期时间
+	cache.SetWithOptions("session", toBytes("data"), SetOptions{IdleTimeout: 30 * time.Millisecond})
+	if err := cache.Touch("session", time.Hour); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, err := cache.Get("session"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("idle key after Touch error = %v, want ErrKeyNotFound", err)
+	}
+}
@@ -0,0 +1,172 @@
+package tscache
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// TypedKey is the set of key types supported by TypedCache. Every key must map to a
+// string that no other key of the same type maps to, otherwise distinct keys would
+// share one cache entry; strings and integers have such a representation.
+type TypedKey interface {
+	~string | ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// TypedCache is a type-safe view over a Cache that stores values of type V under keys of type K.
+// Values are converted with a Codec before being stored, so compression, memory accounting
+// and eviction all operate on the encoded form exactly as they do for Cache.
+//
+// Keys are converted to strings from their underlying value: string keys are used as-is and
+// integer keys are written in decimal, ignoring any String method, so distinct keys never
+// share an entry. Typed caches sharing the same underlying Cache must still use key spaces
+// that do not collide, since for example the int key 1 and the string key "1" both map to "1".
+type TypedCache[K TypedKey, V any] struct {
+	cache *Cache   // Underlying byte-oriented cache
+	codec Codec[V] // Codec converting values to and from bytes
+}
+
+// NewTypedCache creates a typed view over an existing cache.
+//
+// Parameters:
+//   - cache: The underlying cache storing the encoded values
+//   - codec: Codec used to encode and decode values
+//
+// Returns:
+//   - *TypedCache[K, V]: A new typed cache ready for use
+//
+// Example usage:
+//
+//	users := NewTypedCache[int, User](NewCache(), NewJSONCodec[User]())
+//	err := users.Set(42, User{Name: "alice"}, time.Minute)
+//	user, err := users.Get(42)
+func NewTypedCache[K TypedKey, V any](cache *Cache, codec Codec[V]) *TypedCache[K, V] {
+	return &TypedCache[K, V]{
+		cache: cache,
+		codec: codec,
+	}
+}
+
+// Cache returns the underlying cache, for example to read statistics or to close it.
+func (tc *TypedCache[K, V]) Cache() *Cache {
+	return tc.cache
+}
+
+// Set encodes and stores a value with an optional TTL.
+//
+// Parameters:
+//   - key: The cache key
+//   - value: The value to store
+//   - ttl: Time to live duration (0 for no expiration)
+//
+// Returns:
+//   - error: nil on success, the codec's error if encoding fails
+func (tc *TypedCache[K, V]) Set(key K, value V, ttl time.Duration) error {
+	data, err := tc.codec.Encode(value)
+	if err != nil {
+		return fmt.Errorf("tscache: encode value: %w", err)
+	}
+	return tc.cache.Set(formatKey(key), data, ttl)
+}
+
+// Get retrieves and decodes a value.
+//
+// Parameters:
+//   - key: The cache key to lookup
+//
+// Returns:
+//   - V: The cached value (zero value if not found)
+//   - error: nil if found, ErrKeyNotFound if missing, the codec's error if decoding fails
+func (tc *TypedCache[K, V]) Get(key K) (V, error) {
+	data, err := tc.cache.Get(formatKey(key))
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return tc.decode(data)
+}
+
+// GetOrLoad returns the cached value for key, loading and storing it on a miss.
+//
+// Parameters:
+//   - key: The cache key to lookup
+//   - ttl: Time to live for the loaded value (0 for no expiration)
+//   - loader: Function producing the value when the key is not cached
+//
+// Returns:
+//   - V: The cached or freshly loaded value
+//   - error: nil on success, the loader's or codec's error otherwise
+//
+// Concurrent misses on the same key share a single loader invocation, as with Cache.GetOrLoad.
+func (tc *TypedCache[K, V]) GetOrLoad(key K, ttl time.Duration, loader func() (V, error)) (V, error) {
+	data, err := tc.cache.GetOrLoad(formatKey(key), ttl, func() ([]byte, error) {
+		value, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		data, err := tc.codec.Encode(value)
+		if err != nil {
+			return nil, fmt.Errorf("tscache: encode value: %w", err)
+		}
+		return data, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return tc.decode(data)
+}
+
+// Delete removes a value from the cache.
+//
+// Parameters:
+//   - key: The cache key to remove
+func (tc *TypedCache[K, V]) Delete(key K) {
+	tc.cache.Delete(formatKey(key))
+}
+
+// decode converts stored bytes back into a value.
+//
+// Parameters:
+//   - data: Encoded value
+//
+// Returns:
+//   - V: The decoded value
+//   - error: The codec's error, if any
+func (tc *TypedCache[K, V]) decode(data []byte) (V, error) {
+	value, err := tc.codec.Decode(data)
+	if err != nil {
+		var zero V
+		return zero, fmt.Errorf("tscache: decode value: %w", err)
+	}
+	return value, nil
+}
+
+// formatKey converts a typed key into the string key used by the underlying cache.
+//
+// Parameters:
+//   - key: The typed key
+//
+// Returns:
+//   - string: The key itself for string kinds, its decimal form for integer kinds
+func formatKey[K TypedKey](key K) string {
+	switch k := any(key).(type) {
+	case string:
+		return k
+	case int:
+		return strconv.Itoa(k)
+	}
+
+	// Named key types are formatted by their underlying kind
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	default:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+}
@@ -0,0 +1,140 @@
+package tscache
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type typedTestUser struct {
+	ID   int
+	Name string
+	Tags []string
+}
+
+func TestTypedCacheCodecs(t *testing.T) {
+	user := typedTestUser{ID: 42, Name: "alice", Tags: []string{"admin", "beta"}}
+
+	codecs := map[string]Codec[typedTestUser]{
+		"json": NewJSONCodec[typedTestUser](),
+		"gob":  NewGobCodec[typedTestUser](),
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			users := NewTypedCache[int, typedTestUser](NewCache(WithMaxSize(1024*1024)), codec)
+
+			if err := users.Set(user.ID, user, 0); err != nil {
+				t.Fatalf("Set failed: %v", err)
+			}
+
+			got, err := users.Get(user.ID)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if got.ID != user.ID || got.Name != user.Name || len(got.Tags) != 2 {
+				t.Errorf("Get returned %+v, want %+v", got, user)
+			}
+
+			// 整数key会被转换为字符串key
+			if _, err := users.Cache().Get("42"); err != nil {
+				t.Errorf("underlying key should be \"42\": %v", err)
+			}
+
+			users.Delete(user.ID)
+			if _, err := users.Get(user.ID); !errors.Is(err, ErrKeyNotFound) {
+				t.Errorf("Get after Delete error = %v, want ErrKeyNotFound", err)
+			}
+		})
+	}
+}
+
+func TestTypedCacheBytesCodec(t *testing.T) {
+	// 压缩与内存统计基于编码后的数据
+	cache := NewCache(WithMaxSize(1024*1024), WithCompressor(NewGzipCompressor()), WithCompressSize(64))
+	blobs := NewTypedCache[string, []byte](cache, NewBytesCodec())
+
+	large := []byte(strings.Repeat("compressible ", 100))
+	if err := blobs.Set("blob", large, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	got, err := blobs.Get("blob")
+	if err != nil || string(got) != string(large) {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if size := cache.Stats().CurrentSize; size >= len(large) {
+		t.Errorf("CurrentSize = %d, expected compressed size below %d", size, len(large))
+	}
+}
+
+func TestTypedCacheGetOrLoad(t *testing.T) {
+	counts := NewTypedCache[string, int](NewCache(WithMaxSize(1024*1024)), NewJSONCodec[int]())
+
+	calls := 0
+	loader := func() (int, error) {
+		calls++
+		return 7, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := counts.GetOrLoad("seven", 0, loader)
+		if err != nil || value != 7 {
+			t.Errorf("GetOrLoad = %d, %v, want 7", value, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("loader called %d times, want 1", calls)
+	}
+
+	// 解码失败时返回错误
+	counts.Cache().Set("broken", toBytes("not a number"), 0)
+	if _, err := counts.Get("broken"); err == nil {
+		t.Error("expected decode error")
+	}
+}
+
+// typedTestLabel 的String方法对所有键返回相同结果
+type typedTestLabel string
+
+func (typedTestLabel) String() string { return "label" }
+
+// typedTestID 是以整数为底层类型的键
+type typedTestID int64
+
+func (typedTestID) String() string { return "id" }
+
+func TestTypedCacheKeysDoNotCollide(t *testing.T) {
+	// String方法相同的不同键不应共享同一条目
+	labels := NewTypedCache[typedTestLabel, string](NewCache(WithMaxSize(1024*1024)), NewJSONCodec[string]())
+	labels.Set("a b", "first", 0)
+	labels.Set("a", "second", 0)
+	if value, err := labels.Get("a b"); err != nil || value != "first" {
+		t.Errorf("Get(a b) = %q, %v; want first", value, err)
+	}
+
+	ids := NewTypedCache[typedTestID, string](NewCache(WithMaxSize(1024*1024)), NewJSONCodec[string]())
+	ids.Set(-1, "minus one", 0)
+	ids.Set(1, "one", 0)
+	if value, err := ids.Get(-1); err != nil || value != "minus one" {
+		t.Errorf("Get(-1) = %q, %v; want minus one", value, err)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"string", formatKey("a b"), "a b"},
+		{"named string", formatKey(typedTestLabel("a b")), "a b"},
+		{"int", formatKey(-42), "-42"},
+		{"named int", formatKey(typedTestID(-42)), "-42"},
+		{"uint64", formatKey(uint64(1 << 63)), "9223372036854775808"},
+		{"uint8", formatKey(uint8(255)), "255"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("formatKey(%s) = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
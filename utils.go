@@ -34,7 +34,7 @@ func calculateSize(value any) int64 {
 //
 // This is the core implementation that handles different Go types:
 // - Basic types: Use their known sizes
-// - Pointers: Add size of pointed-to value
+// - Pointers: Add size of pointed-to value, once per distinct pointer
 // - Slices: Calculate header + element sizes
 // - Maps: Estimate based on key/value types and length
 // - Structs: Sum all field sizes
@@ -45,7 +45,35 @@ func calculateSize(value any) int64 {
 //
 // Returns:
 //   - int64: Estimated size in bytes
+//
+// Every pointer target is counted only the first time it is reached, so shared
+// values are not counted twice and cyclic structures such as doubly linked lists
+// or parent pointers terminate.
 func calculateValueSize(val reflect.Value) int64 {
+	var walk sizeWalk
+	return walk.valueSize(val)
+}
+
+// visitedPointer identifies a pointer target by address and type. The type is
+// needed because a struct and its first field share the same address.
+type visitedPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// sizeWalk holds the state of a single size calculation.
+type sizeWalk struct {
+	visited map[visitedPointer]struct{} // Pointer targets already counted
+}
+
+// valueSize recursively calculates the size of a reflect.Value, see calculateValueSize.
+//
+// Parameters:
+//   - val: reflect.Value to measure
+//
+// Returns:
+//   - int64: Estimated size in bytes
+func (w *sizeWalk) valueSize(val reflect.Value) int64 {
 	if !val.IsValid() {
 		return 0
 	}
@@ -117,7 +145,17 @@ func calculateValueSize(val reflect.Value) int64 {
 		if val.IsNil() {
 			return ptrSize
 		}
-		return ptrSize + calculateValueSize(val.Elem())
+
+		target := visitedPointer{addr: val.Pointer(), typ: val.Type()}
+		if _, seen := w.visited[target]; seen {
+			return ptrSize // Already counted through another pointer
+		}
+		if w.visited == nil {
+			w.visited = make(map[visitedPointer]struct{})
+		}
+		w.visited[target] = struct{}{}
+
+		return ptrSize + w.valueSize(val.Elem())
 
 	case reflect.Interface:
 		// Interface header + concrete value
@@ -125,14 +163,14 @@ func calculateValueSize(val reflect.Value) int64 {
 		if val.IsNil() {
 			return interfaceSize
 		}
-		return interfaceSize + calculateValueSize(val.Elem())
+		return interfaceSize + w.valueSize(val.Elem())
 
 	case reflect.Struct:
 		// Sum of all field sizes
 		var totalSize int64
 		for i := 0; i < val.NumField(); i++ {
 			field := val.Field(i)
-			totalSize += calculateValueSize(field)
+			totalSize += w.valueSize(field)
 		}
 		return totalSize
 
@@ -422,3 +460,170 @@ func getMemoryUsage() (int64, int64) {
 
 	return int64(m.Alloc), int64(m.Sys)
 }
+
+// matchGlob reports whether str matches a Redis-style glob pattern.
+//
+// Supported syntax:
+// - *: matches any sequence of bytes, including an empty one
+// - ?: matches exactly one byte
+// - [abc], [a-z]: matches one byte from the set or range ([^...] or [!...] negates it)
+// - \x: matches the byte x literally
+//
+// Parameters:
+//   - pattern: Glob pattern, previously checked with validateGlob
+//   - str: String to match
+//
+// Returns:
+//   - bool: true if the whole string matches the pattern
+//
+// Matching works on bytes and backtracks only to the most recent '*', which keeps
+// it linear in the common case and O(len(pattern)*len(str)) in the worst case.
+func matchGlob(pattern, str string) bool {
+	px, sx := 0, 0
+	starPx, starSx := -1, -1
+
+	for px < len(pattern) || sx < len(str) {
+		if px < len(pattern) {
+			switch c := pattern[px]; c {
+			case '*':
+				// Remember where to resume if the rest fails to match
+				starPx, starSx = px, sx
+				px++
+				continue
+			case '?':
+				if sx < len(str) {
+					px++
+					sx++
+					continue
+				}
+			case '[':
+				if sx < len(str) {
+					if matched, width := matchGlobClass(pattern[px:], str[sx]); matched {
+						px += width
+						sx++
+						continue
+					}
+				}
+			case '\\':
+				if px+1 < len(pattern) && sx < len(str) && pattern[px+1] == str[sx] {
+					px += 2
+					sx++
+					continue
+				}
+			default:
+				if sx < len(str) && str[sx] == c {
+					px++
+					sx++
+					continue
+				}
+			}
+		}
+
+		// Let the last '*' absorb one more byte and retry
+		if starPx >= 0 && starSx < len(str) {
+			starSx++
+			px, sx = starPx+1, starSx
+			continue
+		}
+		return false
+	}
+
+	return true
+}
+
+// matchGlobClass matches a byte against the character class at the start of pattern.
+//
+// Parameters:
+//   - pattern: Pattern starting with '['
+//   - b: Byte to match
+//
+// Returns:
+//   - bool: true if the byte belongs to the class
+//   - int: Length of the class in the pattern, including both brackets
+func matchGlobClass(pattern string, b byte) (bool, int) {
+	i := 1
+	negate := false
+	if i < len(pattern) && (pattern[i] == '^' || pattern[i] == '!') {
+		negate = true
+		i++
+	}
+
+	matched := false
+	for first := true; i < len(pattern) && (first || pattern[i] != ']'); first = false {
+		lo := pattern[i]
+		if lo == '\\' && i+1 < len(pattern) {
+			i++
+			lo = pattern[i]
+		}
+		i++
+
+		hi := lo
+		if i+1 < len(pattern) && pattern[i] == '-' && pattern[i+1] != ']' {
+			hi = pattern[i+1]
+			if hi == '\\' && i+2 < len(pattern) {
+				i++
+				hi = pattern[i+1]
+			}
+			i += 2
+		}
+
+		if lo <= b && b <= hi {
+			matched = true
+		}
+	}
+
+	return matched != negate, i + 1
+}
+
+// validateGlob checks that every character class in a glob pattern is terminated
+// and that the pattern does not end with a dangling escape.
+//
+// Parameters:
+//   - pattern: Glob pattern to check
+//
+// Returns:
+//   - error: nil if the pattern is well-formed, ErrBadPattern otherwise
+func validateGlob(pattern string) error {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if i+1 >= len(pattern) {
+				return ErrBadPattern
+			}
+			i++
+		case '[':
+			_, width := matchGlobClass(pattern[i:], 0)
+			if i+width > len(pattern) {
+				return ErrBadPattern
+			}
+			i += width - 1
+		}
+	}
+	return nil
+}
+
+// globPrefix returns the literal prefix of a glob pattern, i.e. the bytes every
+// matching string must start with.
+//
+// Parameters:
+//   - pattern: Glob pattern
+//
+// Returns:
+//   - string: Literal prefix preceding the first wildcard
+func globPrefix(pattern string) string {
+	var prefix []byte
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*', '?', '[':
+			return string(prefix)
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				prefix = append(prefix, pattern[i])
+			}
+		default:
+			prefix = append(prefix, c)
+		}
+	}
+	return string(prefix)
+}
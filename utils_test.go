@@ -104,6 +104,37 @@ func TestCalculateValueSize(t *testing.T) {
 	})
 }
 
+// sizeTestNode 用于测试循环引用的链表节点
+type sizeTestNode struct {
+	Value int
+	Next  *sizeTestNode
+}
+
+func TestCalculateSizeCycles(t *testing.T) {
+	// 指针和int均按8字节估算
+	const word = 8
+
+	// 循环引用的结构只计算一次，不会无限递归：两个指针加两个节点（Value和Next）
+	a, b := &sizeTestNode{Value: 1}, &sizeTestNode{Value: 2}
+	a.Next, b.Next = b, a
+	if size, want := calculateSize(a), int64(5*word); size != want {
+		t.Errorf("calculateSize(cycle) = %d, want %d", size, want)
+	}
+
+	self := &sizeTestNode{}
+	self.Next = self
+	if size, want := calculateSize(self), int64(3*word); size != want {
+		t.Errorf("calculateSize(self cycle) = %d, want %d", size, want)
+	}
+
+	// 多个指针指向同一对象时只计算一次：两个指针加一个节点
+	shared := &sizeTestNode{Value: 3}
+	pair := struct{ First, Second *sizeTestNode }{shared, shared}
+	if size, want := calculateSize(pair), int64(4*word); size != want {
+		t.Errorf("calculateSize(shared) = %d, want %d", size, want)
+	}
+}
+
 func TestFnv1a(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -357,3 +388,59 @@ func TestUtilityFunctionsEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		str     string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"user:*", "user:42", true},
+		{"user:*", "session:42", false},
+		{"user:?", "user:4", true},
+		{"user:?", "user:42", false},
+		{"*:profile", "user:42:profile", true},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-c]llo", "hbllo", true},
+		{"h[a-c]llo", "hdllo", false},
+		{`h\*llo`, "h*llo", true},
+		{`h\*llo`, "hello", false},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.str); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.str, got, tt.want)
+		}
+	}
+}
+
+func TestGlobPrefix(t *testing.T) {
+	tests := map[string]string{
+		"user:42:*":  "user:42:",
+		"user:?":     "user:",
+		"[ab]*":      "",
+		`a\*b*`:      "a*b",
+		"plain":      "plain",
+		"page:[12]:": "page:",
+	}
+
+	for pattern, want := range tests {
+		if got := globPrefix(pattern); got != want {
+			t.Errorf("globPrefix(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+
+	// 格式错误的模式
+	for _, pattern := range []string{"[abc", `abc\`, "[]"} {
+		if err := validateGlob(pattern); err == nil {
+			t.Errorf("validateGlob(%q) should fail", pattern)
+		}
+	}
+}